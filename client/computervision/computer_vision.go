@@ -3,12 +3,18 @@ package computervision
 // Wrapper Client for ComputerVision functions
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/meinside/ms-cognitive-services-go"
 )
 
 type Client struct {
-	Location cognitive.ApiLocation
-	ApiKey   string
+	Location  cognitive.ApiLocation
+	ApiKey    string
+	Cognitive *cognitive.Client // client for sending requests (default: cognitive.DefaultClient)
+
+	Keys cognitive.KeyProvider // provider of subscription keys, with failover and rotation (nil: Location and ApiKey)
 }
 
 func NewClient(apiKey string) *Client {
@@ -25,9 +31,53 @@ func NewClientWithLocation(location cognitive.ApiLocation, apiKey string) *Clien
 	}
 }
 
+func NewClientWithEndpoint(endpoint cognitive.EndpointUrl, apiKey string) *Client {
+	return &Client{
+		ApiKey:    apiKey,
+		Cognitive: &cognitive.Client{Endpoint: endpoint},
+	}
+}
+
+func NewClientWithKeys(keys cognitive.KeyProvider) *Client {
+	return &Client{
+		Keys: keys,
+	}
+}
+
+func NewClientWithCredential(location cognitive.ApiLocation, credential cognitive.Credential) *Client {
+	return &Client{
+		Location:  location,
+		Cognitive: &cognitive.Client{Credential: credential},
+	}
+}
+
+// client for sending requests
+func (c *Client) api() *cognitive.Client {
+	if c.Cognitive != nil {
+		return c.Cognitive
+	}
+	return cognitive.DefaultClient
+}
+
+// call fn with the location and subscription key of the client, or with the keys of Keys in order
+func (c *Client) withKey(ctx context.Context, fn func(location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeys(ctx, c.Keys, fn)
+	}
+	return fn(c.Location, c.ApiKey)
+}
+
+// call fn like withKey(), for requests with media (see cognitive.WithKeysForMedia())
+func (c *Client) withKeyForMedia(ctx context.Context, media interface{}, fn func(ctx context.Context, location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeysForMedia(ctx, c.Keys, media, fn)
+	}
+	return fn(ctx, c.Location, c.ApiKey)
+}
+
 // Analyze Image
 //
-// image          : string(image url) or []byte(image bytes array)
+// image          : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // visualFeatures : "Categories", "Tags", "Description", "Faces", "ImageType", "Color", "Adult"
 // details        : "Celebrities", "Landmarks"
 // language       : "en" or "zh" (default: "en")
@@ -37,36 +87,124 @@ func (c *Client) AnalyzeImage(
 	details []string,
 	language string,
 ) (processResult cognitive.ComputerVisionImageAnalyzeResult, err error) {
-	return cognitive.ComputerVisionAnalyzeImage(
-		c.Location,
-		c.ApiKey,
+	return c.AnalyzeImageWithContext(
+		context.Background(),
 		image,
 		visualFeatures,
 		details,
 		language,
 	)
+}
+
+// Analyze Image (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) AnalyzeImageWithContext(
+	ctx context.Context,
+	image interface{},
+	visualFeatures []string,
+	details []string,
+	language string,
+) (processResult cognitive.ComputerVisionImageAnalyzeResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionAnalyzeImage(
+			ctx,
+			location,
+			key,
+			image,
+			visualFeatures,
+			details,
+			language,
+		)
+		return err
+	})
+	return processResult, err
+
+}
 
+// Analyze Image (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithVisualFeatures(), cognitive.WithDetails(), cognitive.WithLanguage()
+func (c *Client) AnalyzeImageWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.ComputerVisionImageAnalyzeResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionAnalyzeImageWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
 }
 
 // Describe Image
 //
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // maxCandidates : default 1
 func (c *Client) DescribeImage(
 	image interface{},
 	maxCandidates int,
 ) (processResult cognitive.ComputerVisionImageDescribeResult, err error) {
-	return cognitive.ComputerVisionDescribeImage(
-		c.Location,
-		c.ApiKey,
+	return c.DescribeImageWithContext(
+		context.Background(),
 		image,
 		maxCandidates,
 	)
 }
 
+// Describe Image (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DescribeImageWithContext(
+	ctx context.Context,
+	image interface{},
+	maxCandidates int,
+) (processResult cognitive.ComputerVisionImageDescribeResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionDescribeImage(
+			ctx,
+			location,
+			key,
+			image,
+			maxCandidates,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Describe Image (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithMaxCandidates()
+func (c *Client) DescribeImageWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.ComputerVisionImageDescribeResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionDescribeImageWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Get Thumbnail
 //
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // width         : 1 ~ 1024 (recommended: minimum 50)
 // height        : 1 ~ 1024 (recommended: minimum 50)
 // smartCropping :
@@ -76,9 +214,8 @@ func (c *Client) GetThumbnail(
 	height int,
 	smartCropping bool,
 ) (processResult []byte, err error) {
-	return cognitive.ComputerVisionGetThumbnail(
-		c.Location,
-		c.ApiKey,
+	return c.GetThumbnailWithContext(
+		context.Background(),
 		image,
 		width,
 		height,
@@ -86,17 +223,80 @@ func (c *Client) GetThumbnail(
 	)
 }
 
+// Get Thumbnail (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetThumbnailWithContext(
+	ctx context.Context,
+	image interface{},
+	width int,
+	height int,
+	smartCropping bool,
+) (processResult []byte, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionGetThumbnail(
+			ctx,
+			location,
+			key,
+			image,
+			width,
+			height,
+			smartCropping,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Get Thumbnail (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithSmartCropping()
+func (c *Client) GetThumbnailWithOptions(
+	ctx context.Context,
+	image interface{},
+	width int,
+	height int,
+	opts ...cognitive.Option,
+) (processResult []byte, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionGetThumbnailWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			width,
+			height,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // List Domain Specific Models
 func (c *Client) GetModels() (processResult cognitive.ComputerVisionDomainSpecificModelsResult, err error) {
-	return cognitive.ComputerVisionGetModels(
-		c.Location,
-		c.ApiKey,
-	)
+	return c.GetModelsWithContext(context.Background())
+}
+
+// List Domain Specific Models (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetModelsWithContext(ctx context.Context) (processResult cognitive.ComputerVisionDomainSpecificModelsResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionGetModels(
+			ctx,
+			location,
+			key,
+		)
+		return err
+	})
+	return processResult, err
 }
 
 // OCR
 //
-// image             : string(image url) or []byte(image bytes array)
+// image             : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // language          : "unk", "zh-Hans", "zh-Hant", "cs", "da", "nl", "en", "fi", "fr", "de", "el", "hu", "it", "ja", "ko", "nb", "pl", "pt", "ru", "es", "sv", "tr" (default: "unk")
 // detectOrientation :
 func (c *Client) Ocr(
@@ -104,34 +304,98 @@ func (c *Client) Ocr(
 	language string,
 	detectOrientation bool,
 ) (processResult cognitive.ComputerVisionOcrResult, err error) {
-	return cognitive.ComputerVisionOcr(
-		c.Location,
-		c.ApiKey,
+	return c.OcrWithContext(
+		context.Background(),
 		image,
 		language,
 		detectOrientation,
 	)
 }
 
+// OCR (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) OcrWithContext(
+	ctx context.Context,
+	image interface{},
+	language string,
+	detectOrientation bool,
+) (processResult cognitive.ComputerVisionOcrResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionOcr(
+			ctx,
+			location,
+			key,
+			image,
+			language,
+			detectOrientation,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// OCR (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithLanguage(), cognitive.WithDetectOrientation()
+func (c *Client) OcrWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.ComputerVisionOcrResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionOcrWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Recognize Domain Specific Content
 //
-// image : string(image url) or []byte(image bytes array)
+// image : cognitive.Media, string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // model :
 func (c *Client) DomainSpecificRecognize(
 	image interface{},
 	model string,
 ) (processResult cognitive.ComputerVisionDomainSpecificResult, err error) {
-	return cognitive.ComputerVisionDomainSpecificRecognize(
-		c.Location,
-		c.ApiKey,
+	return c.DomainSpecificRecognizeWithContext(
+		context.Background(),
 		image,
 		model,
 	)
 }
 
+// Recognize Domain Specific Content (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DomainSpecificRecognizeWithContext(
+	ctx context.Context,
+	image interface{},
+	model string,
+) (processResult cognitive.ComputerVisionDomainSpecificResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionDomainSpecificRecognize(
+			ctx,
+			location,
+			key,
+			image,
+			model,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Recognize Handwritten Text
 //
-// image            : string(image url) or []byte(image bytes array)
+// image            : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // handwriting      : (default: false)
 // progressNotifier : can be nil
 func (c *Client) RecognizeHandwritten(
@@ -139,24 +403,146 @@ func (c *Client) RecognizeHandwritten(
 	handwriting bool,
 	progressNotifier func(status string, progress float32),
 ) (processResult cognitive.ComputerVisionHandwrittenProcessingResult, err error) {
-	return cognitive.ComputerVisionRecognizeHandwritten(
-		c.Location,
-		c.ApiKey,
+	return c.RecognizeHandwrittenWithContext(
+		context.Background(),
 		image,
 		handwriting,
 		progressNotifier,
 	)
 }
 
+// Recognize Handwritten Text (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func (c *Client) RecognizeHandwrittenWithContext(
+	ctx context.Context,
+	image interface{},
+	handwriting bool,
+	progressNotifier func(status string, progress float32),
+) (processResult cognitive.ComputerVisionHandwrittenProcessingResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionRecognizeHandwritten(
+			ctx,
+			location,
+			key,
+			image,
+			handwriting,
+			progressNotifier,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Recognize Handwritten Text (with options)
+//
+// ctx  : cancels the request and the polling for its result when done
+// opts : cognitive.WithHandwriting(), cognitive.WithProgress()
+func (c *Client) RecognizeHandwrittenWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.ComputerVisionHandwrittenProcessingResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionRecognizeHandwrittenWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Recognize Handwritten Text (submit)
+//
+// ctx : cancels the request when done
+//
+// submits the image and returns its operation, without waiting for the result
+func (c *Client) RecognizeHandwrittenSubmit(
+	ctx context.Context,
+	image interface{},
+	handwriting bool,
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().ComputerVisionRecognizeHandwrittenSubmit(
+			ctx,
+			location,
+			key,
+			image,
+			handwriting,
+		)
+		return err
+	})
+	return op, err
+}
+
+// Recognize Handwritten Text (submit with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithHandwriting()
+func (c *Client) RecognizeHandwrittenSubmitWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().ComputerVisionRecognizeHandwrittenSubmitWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return op, err
+}
+
 // Tag Image
 //
-// image : string(image url) or []byte(image bytes array)
+// image : cognitive.Media, string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 func (c *Client) TagImage(
 	image interface{},
 ) (processResult cognitive.ComputerVisionTagImageResult, err error) {
-	return cognitive.ComputerVisionTagImage(
-		c.Location,
-		c.ApiKey,
+	return c.TagImageWithContext(
+		context.Background(),
 		image,
 	)
 }
+
+// Tag Image (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) TagImageWithContext(
+	ctx context.Context,
+	image interface{},
+) (processResult cognitive.ComputerVisionTagImageResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().ComputerVisionTagImage(
+			ctx,
+			location,
+			key,
+			image,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Resume an operation (eg. submitted before the worker restarted)
+//
+// handle : handle of a handwriting recognition operation, stored from Operation.Handle() or its json
+func (c *Client) Resume(
+	handle cognitive.OperationHandle,
+) (op *cognitive.Operation, err error) {
+	if handle.Kind.Service() != cognitive.ServiceComputerVision {
+		return nil, fmt.Errorf("Not a computer vision operation: %s", handle.Kind)
+	}
+	if c.Keys != nil {
+		return c.api().ResumeOperationWithKeys(c.Keys, handle)
+	}
+	return c.api().ResumeOperation(c.Location, c.ApiKey, handle)
+}
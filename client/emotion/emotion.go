@@ -1,37 +1,133 @@
 package emotion
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/meinside/ms-cognitive-services-go"
 )
 
 type Client struct {
-	ApiKey string
+	Location  cognitive.ApiLocation
+	ApiKey    string
+	Cognitive *cognitive.Client // client for sending requests (default: cognitive.DefaultClient)
+
+	Keys cognitive.KeyProvider // provider of subscription keys, with failover and rotation (nil: Location and ApiKey)
 }
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		ApiKey: apiKey,
+		Location: cognitive.WestUS,
+		ApiKey:   apiKey,
+	}
+}
+
+func NewClientWithLocation(location cognitive.ApiLocation, apiKey string) *Client {
+	return &Client{
+		Location: location,
+		ApiKey:   apiKey,
+	}
+}
+
+func NewClientWithEndpoint(endpoint cognitive.EndpointUrl, apiKey string) *Client {
+	return &Client{
+		ApiKey:    apiKey,
+		Cognitive: &cognitive.Client{Endpoint: endpoint},
+	}
+}
+
+func NewClientWithKeys(keys cognitive.KeyProvider) *Client {
+	return &Client{
+		Keys: keys,
+	}
+}
+
+func NewClientWithCredential(location cognitive.ApiLocation, credential cognitive.Credential) *Client {
+	return &Client{
+		Location:  location,
+		Cognitive: &cognitive.Client{Credential: credential},
 	}
 }
 
+// client for sending requests
+func (c *Client) api() *cognitive.Client {
+	if c.Cognitive != nil {
+		return c.Cognitive
+	}
+	return cognitive.DefaultClient
+}
+
+// call fn with the location and subscription key of the client, or with the keys of Keys in order
+func (c *Client) withKey(ctx context.Context, fn func(location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeys(ctx, c.Keys, fn)
+	}
+	return fn(c.Location, c.ApiKey)
+}
+
+// call fn like withKey(), for requests with media (see cognitive.WithKeysForMedia())
+func (c *Client) withKeyForMedia(ctx context.Context, media interface{}, fn func(ctx context.Context, location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeysForMedia(ctx, c.Keys, media, fn)
+	}
+	return fn(ctx, c.Location, c.ApiKey)
+}
+
 // Emotion Recognition in Image
 //
-// image : string(image url) or []byte(image bytes array)
+// image : cognitive.Media, string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // rects : rectangles of faces (can be nil if none)
 func (c *Client) RecognizeImage(
 	image interface{},
 	rects []cognitive.Rectangle,
 ) (emotions []cognitive.Emotion, err error) {
-	return cognitive.EmotionRecognizeImage(
-		c.ApiKey,
+	return c.RecognizeImageWithContext(
+		context.Background(),
 		image,
 		rects,
 	)
 }
 
+// Emotion Recognition in Image (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) RecognizeImageWithContext(
+	ctx context.Context,
+	image interface{},
+	rects []cognitive.Rectangle,
+) (emotions []cognitive.Emotion, err error) {
+	return c.RecognizeImageWithOptions(
+		ctx,
+		image,
+		cognitive.WithFaceRectangles(rects...),
+	)
+}
+
+// Emotion Recognition in Image (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithFaceRectangles()
+func (c *Client) RecognizeImageWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (emotions []cognitive.Emotion, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		emotions, err = c.api().EmotionRecognizeImageWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return emotions, err
+}
+
 // Emotion Recognition in Video
 //
-// video            : string(video url) or []byte(video bytes array)
+// video            : string(video url), []byte(video bytes array), cognitive.File(video file path), or io.Reader(video stream)
 // outputStyle      : "aggregate" (default) or "perFrame"
 // progressNotifier : can be nil
 func (c *Client) RecognizeVideo(
@@ -39,10 +135,103 @@ func (c *Client) RecognizeVideo(
 	outputStyle string,
 	progressNotifier func(status string, progress float32),
 ) (processResult cognitive.EmotionProcessingResult, err error) {
-	return cognitive.EmotionRecognizeVideo(
-		c.ApiKey,
+	return c.RecognizeVideoWithContext(
+		context.Background(),
 		video,
 		outputStyle,
 		progressNotifier,
 	)
 }
+
+// Emotion Recognition in Video (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func (c *Client) RecognizeVideoWithContext(
+	ctx context.Context,
+	video interface{},
+	outputStyle string,
+	progressNotifier func(status string, progress float32),
+) (processResult cognitive.EmotionProcessingResult, err error) {
+	return c.RecognizeVideoWithOptions(
+		ctx,
+		video,
+		cognitive.WithOutputStyle(cognitive.OutputStyle(outputStyle)),
+		cognitive.WithProgress(progressNotifier),
+	)
+}
+
+// Emotion Recognition in Video (with options)
+//
+// ctx  : cancels the request and the polling for its result when done
+// opts : cognitive.WithOutputStyle(), cognitive.WithProgress()
+func (c *Client) RecognizeVideoWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.EmotionProcessingResult, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().EmotionRecognizeVideoWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Emotion Recognition in Video (submit)
+//
+// ctx : cancels the request when done
+//
+// submits the video and returns its operation, without waiting for the result
+func (c *Client) RecognizeVideoSubmit(
+	ctx context.Context,
+	video interface{},
+	outputStyle string,
+) (op *cognitive.Operation, err error) {
+	return c.RecognizeVideoSubmitWithOptions(
+		ctx,
+		video,
+		cognitive.WithOutputStyle(cognitive.OutputStyle(outputStyle)),
+	)
+}
+
+// Emotion Recognition in Video (submit with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithOutputStyle()
+func (c *Client) RecognizeVideoSubmitWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().EmotionRecognizeVideoSubmitWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return op, err
+}
+
+// Resume an operation (eg. submitted before the worker restarted)
+//
+// handle : handle of an emotion-in-video operation, stored from Operation.Handle() or its json
+func (c *Client) Resume(
+	handle cognitive.OperationHandle,
+) (op *cognitive.Operation, err error) {
+	if handle.Kind.Service() != cognitive.ServiceEmotion {
+		return nil, fmt.Errorf("Not an emotion operation: %s", handle.Kind)
+	}
+	if c.Keys != nil {
+		return c.api().ResumeOperationWithKeys(c.Keys, handle)
+	}
+	return c.api().ResumeOperation(c.Location, c.ApiKey, handle)
+}
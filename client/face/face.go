@@ -3,12 +3,17 @@ package face
 // Wrapper Client for Face functions
 
 import (
+	"context"
+
 	"github.com/meinside/ms-cognitive-services-go"
 )
 
 type Client struct {
-	Location cognitive.ApiLocation
-	ApiKey   string
+	Location  cognitive.ApiLocation
+	ApiKey    string
+	Cognitive *cognitive.Client // client for sending requests (default: cognitive.DefaultClient)
+
+	Keys cognitive.KeyProvider // provider of subscription keys, with failover and rotation (nil: Location and ApiKey)
 }
 
 func NewClient(apiKey string) *Client {
@@ -25,9 +30,53 @@ func NewClientWithLocation(location cognitive.ApiLocation, apiKey string) *Clien
 	}
 }
 
+func NewClientWithEndpoint(endpoint cognitive.EndpointUrl, apiKey string) *Client {
+	return &Client{
+		ApiKey:    apiKey,
+		Cognitive: &cognitive.Client{Endpoint: endpoint},
+	}
+}
+
+func NewClientWithKeys(keys cognitive.KeyProvider) *Client {
+	return &Client{
+		Keys: keys,
+	}
+}
+
+func NewClientWithCredential(location cognitive.ApiLocation, credential cognitive.Credential) *Client {
+	return &Client{
+		Location:  location,
+		Cognitive: &cognitive.Client{Credential: credential},
+	}
+}
+
+// client for sending requests
+func (c *Client) api() *cognitive.Client {
+	if c.Cognitive != nil {
+		return c.Cognitive
+	}
+	return cognitive.DefaultClient
+}
+
+// call fn with the location and subscription key of the client, or with the keys of Keys in order
+func (c *Client) withKey(ctx context.Context, fn func(location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeys(ctx, c.Keys, fn)
+	}
+	return fn(c.Location, c.ApiKey)
+}
+
+// call fn like withKey(), for requests with media (see cognitive.WithKeysForMedia())
+func (c *Client) withKeyForMedia(ctx context.Context, media interface{}, fn func(ctx context.Context, location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeysForMedia(ctx, c.Keys, media, fn)
+	}
+	return fn(ctx, c.Location, c.ApiKey)
+}
+
 // Detect
 //
-// image                : string(image url) or []byte(image bytes array)
+// image                : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // returnFaceId         : (default: true)
 // returnFaceLandmarks  : (default: false)
 // returnFaceAttributes : "age", "gender", "headPose", "smile", "facialHair", "glasses", or "emotion"
@@ -37,9 +86,8 @@ func (c *Client) Detect(
 	returnFaceLandmarks bool,
 	returnFaceAttributes []string,
 ) (processResult []cognitive.FaceDetectResult, err error) {
-	return cognitive.FaceDetect(
-		c.Location,
-		c.ApiKey,
+	return c.DetectWithContext(
+		context.Background(),
 		image,
 		returnFaceId,
 		returnFaceLandmarks,
@@ -47,12 +95,59 @@ func (c *Client) Detect(
 	)
 }
 
+// Detect (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DetectWithContext(
+	ctx context.Context,
+	image interface{},
+	returnFaceId bool,
+	returnFaceLandmarks bool,
+	returnFaceAttributes []string,
+) (processResult []cognitive.FaceDetectResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceDetect(
+			ctx,
+			location,
+			key,
+			image,
+			returnFaceId,
+			returnFaceLandmarks,
+			returnFaceAttributes,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Detect (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithoutFaceId(), cognitive.WithLandmarks(), cognitive.WithAttributes()
+func (c *Client) DetectWithOptions(
+	ctx context.Context,
+	image interface{},
+	opts ...cognitive.Option,
+) (processResult []cognitive.FaceDetectResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceDetectWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Find Similar
 //
 // faceId                     : (can get from FaceDetect())
 // faceListId                 : (can get from FaceListCreate())
 // faceIds                    : (can get from FaceDetect())
-// maxNumOfCandidatesReturned : 1 - 1000 (default: 20)
+// maxNumOfCandidatesReturned : 1 - 1000, or 0 for default (20)
 // mode                       : "matchPerson" or "matchFace" (default: "matchPerson")
 func (c *Client) FindSimilar(
 	faceId string,
@@ -61,9 +156,8 @@ func (c *Client) FindSimilar(
 	maxNumOfCandidatesReturned int,
 	mode string,
 ) (processResult []cognitive.FaceFindSimilarResult, err error) {
-	return cognitive.FaceFindSimilar(
-		c.Location,
-		c.ApiKey,
+	return c.FindSimilarWithContext(
+		context.Background(),
 		faceId,
 		faceListId,
 		faceIds,
@@ -72,34 +166,100 @@ func (c *Client) FindSimilar(
 	)
 }
 
+// Find Similar (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) FindSimilarWithContext(
+	ctx context.Context,
+	faceId string,
+	faceListId string,
+	faceIds []string,
+	maxNumOfCandidatesReturned int,
+	mode string,
+) (processResult []cognitive.FaceFindSimilarResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceFindSimilar(
+			ctx,
+			location,
+			key,
+			faceId,
+			faceListId,
+			faceIds,
+			maxNumOfCandidatesReturned,
+			mode,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Find Similar (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithFaceList(), cognitive.WithFaceIds(), cognitive.WithMaxCandidates(), cognitive.WithMode()
+func (c *Client) FindSimilarWithOptions(
+	ctx context.Context,
+	faceId string,
+	opts ...cognitive.Option,
+) (processResult []cognitive.FaceFindSimilarResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceFindSimilarWithOptions(
+			ctx,
+			location,
+			key,
+			faceId,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Group
 //
 // faceIds : (can get from FaceDetect())
 func (c *Client) Group(
 	faceIds []string,
 ) (processResult cognitive.FaceGroupResult, err error) {
-	return cognitive.FaceGroup(
-		c.Location,
-		c.ApiKey,
+	return c.GroupWithContext(
+		context.Background(),
 		faceIds,
 	)
 }
 
+// Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GroupWithContext(
+	ctx context.Context,
+	faceIds []string,
+) (processResult cognitive.FaceGroupResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGroup(
+			ctx,
+			location,
+			key,
+			faceIds,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Identify
 //
 // faceIds                    : (can get from FaceDetect())
 // personGroupId              : (can get from FacePersonGroup())
-// maxNumOfCandidatesReturned : 1 - 5 (default: 1)
-// confidenceThreshold        : 0.0 - 1.0 (default: set automatically)
+// maxNumOfCandidatesReturned : 1 - 5, or 0 for default (1)
+// confidenceThreshold        : 0.0 - 1.0, or 0.0 for default (set automatically)
 func (c *Client) Identify(
 	faceIds []string,
 	personGroupId string,
 	maxNumOfCandidatesReturned int,
 	confidenceThreshold float64,
 ) (processResult []cognitive.FaceIdentifyResult, err error) {
-	return cognitive.FaceIdentify(
-		c.Location,
-		c.ApiKey,
+	return c.IdentifyWithContext(
+		context.Background(),
 		faceIds,
 		personGroupId,
 		maxNumOfCandidatesReturned,
@@ -107,22 +267,89 @@ func (c *Client) Identify(
 	)
 }
 
+// Identify (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) IdentifyWithContext(
+	ctx context.Context,
+	faceIds []string,
+	personGroupId string,
+	maxNumOfCandidatesReturned int,
+	confidenceThreshold float64,
+) (processResult []cognitive.FaceIdentifyResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceIdentify(
+			ctx,
+			location,
+			key,
+			faceIds,
+			personGroupId,
+			maxNumOfCandidatesReturned,
+			confidenceThreshold,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Identify (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithMaxCandidates(), cognitive.WithConfidenceThreshold()
+func (c *Client) IdentifyWithOptions(
+	ctx context.Context,
+	faceIds []string,
+	personGroupId string,
+	opts ...cognitive.Option,
+) (processResult []cognitive.FaceIdentifyResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceIdentifyWithOptions(
+			ctx,
+			location,
+			key,
+			faceIds,
+			personGroupId,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Verify
 //
 // obj : FaceVerifyRequest1(face-to-face) or FaceVerifyRequest2(face-to-person)
 func (c *Client) Verify(
 	obj interface{},
 ) (processResult cognitive.FaceVerifyResult, err error) {
-	return cognitive.FaceVerify(
-		c.Location,
-		c.ApiKey,
+	return c.VerifyWithContext(
+		context.Background(),
 		obj,
 	)
 }
 
+// Verify (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) VerifyWithContext(
+	ctx context.Context,
+	obj interface{},
+) (processResult cognitive.FaceVerifyResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceVerify(
+			ctx,
+			location,
+			key,
+			obj,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Add a Face to a Face List
 //
-// image      : string(image url) or []byte(image bytes array)
+// image      : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // faceListId : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
 // userData   : max 1kb
 // targetFace : if there're more than 1 faces, it should be passed
@@ -132,9 +359,8 @@ func (c *Client) AddFaceToList(
 	userData string,
 	targetFace cognitive.Rectangle,
 ) (processResult cognitive.FaceAddToListResult, err error) {
-	return cognitive.FaceAddFaceToList(
-		c.Location,
-		c.ApiKey,
+	return c.AddFaceToListWithContext(
+		context.Background(),
 		image,
 		faceListId,
 		userData,
@@ -142,6 +368,55 @@ func (c *Client) AddFaceToList(
 	)
 }
 
+// Add a Face to a Face List (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) AddFaceToListWithContext(
+	ctx context.Context,
+	image interface{},
+	faceListId string,
+	userData string,
+	targetFace cognitive.Rectangle,
+) (processResult cognitive.FaceAddToListResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceAddFaceToList(
+			ctx,
+			location,
+			key,
+			image,
+			faceListId,
+			userData,
+			targetFace,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Add a Face to a Face List (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithUserData(), cognitive.WithTargetFace()
+func (c *Client) AddFaceToListWithOptions(
+	ctx context.Context,
+	image interface{},
+	faceListId string,
+	opts ...cognitive.Option,
+) (processResult cognitive.FaceAddToListResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceAddFaceToListWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			faceListId,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Create a Face List
 //
 // faceListId : id of a new face list
@@ -152,15 +427,35 @@ func (c *Client) CreateFaceList(
 	name string,
 	userData string,
 ) (err error) {
-	return cognitive.FaceCreateFaceList(
-		c.Location,
-		c.ApiKey,
+	return c.CreateFaceListWithContext(
+		context.Background(),
 		faceListId,
 		name,
 		userData,
 	)
 }
 
+// Create a Face List (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) CreateFaceListWithContext(
+	ctx context.Context,
+	faceListId string,
+	name string,
+	userData string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceCreateFaceList(
+			ctx,
+			location,
+			key,
+			faceListId,
+			name,
+			userData,
+		)
+	})
+}
+
 // Delete a Face from a Face List
 //
 // faceListId      : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
@@ -169,46 +464,110 @@ func (c *Client) DeleteFace(
 	faceListId string,
 	persistedFaceId string,
 ) (err error) {
-	return cognitive.FaceDeleteFace(
-		c.Location,
-		c.ApiKey,
+	return c.DeleteFaceWithContext(
+		context.Background(),
 		faceListId,
 		persistedFaceId,
 	)
 }
 
+// Delete a Face from a Face List (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DeleteFaceWithContext(
+	ctx context.Context,
+	faceListId string,
+	persistedFaceId string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceDeleteFace(
+			ctx,
+			location,
+			key,
+			faceListId,
+			persistedFaceId,
+		)
+	})
+}
+
 // Delete a Face List
 //
 // faceListId      : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
 func (c *Client) DeleteFaceList(
 	faceListId string,
 ) (err error) {
-	return cognitive.FaceDeleteFaceList(
-		c.Location,
-		c.ApiKey,
+	return c.DeleteFaceListWithContext(
+		context.Background(),
 		faceListId,
 	)
 }
 
+// Delete a Face List (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DeleteFaceListWithContext(
+	ctx context.Context,
+	faceListId string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceDeleteFaceList(
+			ctx,
+			location,
+			key,
+			faceListId,
+		)
+	})
+}
+
 // Get a Face List
 //
 // faceListId : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
 func (c *Client) GetFaces(
 	faceListId string,
 ) (processResult cognitive.FaceFacesResult, err error) {
-	return cognitive.FaceGetFaces(
-		c.Location,
-		c.ApiKey,
+	return c.GetFacesWithContext(
+		context.Background(),
 		faceListId,
 	)
 }
 
+// Get a Face List (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetFacesWithContext(
+	ctx context.Context,
+	faceListId string,
+) (processResult cognitive.FaceFacesResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetFaces(
+			ctx,
+			location,
+			key,
+			faceListId,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // List Face Lists
 func (c *Client) GetLists() (processResult []cognitive.FaceListResult, err error) {
-	return cognitive.FaceGetLists(
-		c.Location,
-		c.ApiKey,
-	)
+	return c.GetListsWithContext(context.Background())
+}
+
+// List Face Lists (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetListsWithContext(ctx context.Context) (processResult []cognitive.FaceListResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetLists(
+			ctx,
+			location,
+			key,
+		)
+		return err
+	})
+	return processResult, err
 }
 
 // Update a Face List
@@ -221,18 +580,38 @@ func (c *Client) UpdateFaceList(
 	name string,
 	userData string,
 ) (err error) {
-	return cognitive.FaceUpdateFaceList(
-		c.Location,
-		c.ApiKey,
+	return c.UpdateFaceListWithContext(
+		context.Background(),
 		faceListId,
 		name,
 		userData,
 	)
 }
 
+// Update a Face List (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) UpdateFaceListWithContext(
+	ctx context.Context,
+	faceListId string,
+	name string,
+	userData string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceUpdateFaceList(
+			ctx,
+			location,
+			key,
+			faceListId,
+			name,
+			userData,
+		)
+	})
+}
+
 // Add a Person Face
 //
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // personGroupId :
 // personId      :
 // userData      : max 1kb
@@ -244,9 +623,8 @@ func (c *Client) AddPersonFace(
 	userData string,
 	targetFace cognitive.Rectangle,
 ) (processResult cognitive.FaceAddPersonFaceResult, err error) {
-	return cognitive.FaceAddPersonFace(
-		c.Location,
-		c.ApiKey,
+	return c.AddPersonFaceWithContext(
+		context.Background(),
 		image,
 		personGroupId,
 		personId,
@@ -255,9 +633,62 @@ func (c *Client) AddPersonFace(
 	)
 }
 
+// Add a Person Face (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) AddPersonFaceWithContext(
+	ctx context.Context,
+	image interface{},
+	personGroupId string,
+	personId string,
+	userData string,
+	targetFace cognitive.Rectangle,
+) (processResult cognitive.FaceAddPersonFaceResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceAddPersonFace(
+			ctx,
+			location,
+			key,
+			image,
+			personGroupId,
+			personId,
+			userData,
+			targetFace,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Add a Person Face (with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithUserData(), cognitive.WithTargetFace()
+func (c *Client) AddPersonFaceWithOptions(
+	ctx context.Context,
+	image interface{},
+	personGroupId string,
+	personId string,
+	opts ...cognitive.Option,
+) (processResult cognitive.FaceAddPersonFaceResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceAddPersonFaceWithOptions(
+			ctx,
+			location,
+			key,
+			image,
+			personGroupId,
+			personId,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Create a Person
 //
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), cognitive.File(image file path), or io.Reader(image stream)
 // personGroupId :
 // name          : max length = 128
 // userData      : max 16kb
@@ -267,9 +698,8 @@ func (c *Client) CreatePerson(
 	name string,
 	userData string,
 ) (processResult cognitive.FaceCreatePersonResult, err error) {
-	return cognitive.FaceCreatePerson(
-		c.Location,
-		c.ApiKey,
+	return c.CreatePersonWithContext(
+		context.Background(),
 		image,
 		personGroupId,
 		name,
@@ -277,6 +707,31 @@ func (c *Client) CreatePerson(
 	)
 }
 
+// Create a Person (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) CreatePersonWithContext(
+	ctx context.Context,
+	image interface{},
+	personGroupId string,
+	name string,
+	userData string,
+) (processResult cognitive.FaceCreatePersonResult, err error) {
+	err = c.withKeyForMedia(ctx, image, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceCreatePerson(
+			ctx,
+			location,
+			key,
+			image,
+			personGroupId,
+			name,
+			userData,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Delete a Person
 //
 // personGroupId :
@@ -285,14 +740,32 @@ func (c *Client) DeletePerson(
 	personGroupId string,
 	personId string,
 ) (err error) {
-	return cognitive.FaceDeletePerson(
-		c.Location,
-		c.ApiKey,
+	return c.DeletePersonWithContext(
+		context.Background(),
 		personGroupId,
 		personId,
 	)
 }
 
+// Delete a Person (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DeletePersonWithContext(
+	ctx context.Context,
+	personGroupId string,
+	personId string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceDeletePerson(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			personId,
+		)
+	})
+}
+
 // Delete a Person Face
 //
 // personGroupId   :
@@ -303,15 +776,35 @@ func (c *Client) DeletePersonFace(
 	personId string,
 	persistedFaceId string,
 ) (err error) {
-	return cognitive.FaceDeletePersonFace(
-		c.Location,
-		c.ApiKey,
+	return c.DeletePersonFaceWithContext(
+		context.Background(),
 		personGroupId,
 		personId,
 		persistedFaceId,
 	)
 }
 
+// Delete a Person Face (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DeletePersonFaceWithContext(
+	ctx context.Context,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceDeletePersonFace(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			personId,
+			persistedFaceId,
+		)
+	})
+}
+
 // Get a Person
 //
 // personGroupId :
@@ -320,14 +813,34 @@ func (c *Client) GetPerson(
 	personGroupId string,
 	personId string,
 ) (processResult cognitive.FaceGetPersonResult, err error) {
-	return cognitive.FaceGetPerson(
-		c.Location,
-		c.ApiKey,
+	return c.GetPersonWithContext(
+		context.Background(),
 		personGroupId,
 		personId,
 	)
 }
 
+// Get a Person (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetPersonWithContext(
+	ctx context.Context,
+	personGroupId string,
+	personId string,
+) (processResult cognitive.FaceGetPersonResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetPerson(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			personId,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Get a Person Face
 //
 // personGroupId   :
@@ -338,28 +851,68 @@ func (c *Client) GetPersonFace(
 	personId string,
 	persistedFaceId string,
 ) (processResult cognitive.FaceGetPersonFaceResult, err error) {
-	return cognitive.FaceGetPersonFace(
-		c.Location,
-		c.ApiKey,
+	return c.GetPersonFaceWithContext(
+		context.Background(),
 		personGroupId,
 		personId,
 		persistedFaceId,
 	)
 }
 
+// Get a Person Face (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetPersonFaceWithContext(
+	ctx context.Context,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+) (processResult cognitive.FaceGetPersonFaceResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetPersonFace(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			personId,
+			persistedFaceId,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // List Persons in a Person Group
 //
 // personGroupId :
 func (c *Client) GetPersons(
 	personGroupId string,
 ) (processResult []cognitive.FaceGetPersonsResult, err error) {
-	return cognitive.FaceGetPersons(
-		c.Location,
-		c.ApiKey,
+	return c.GetPersonsWithContext(
+		context.Background(),
 		personGroupId,
 	)
 }
 
+// List Persons in a Person Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetPersonsWithContext(
+	ctx context.Context,
+	personGroupId string,
+) (processResult []cognitive.FaceGetPersonsResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetPersons(
+			ctx,
+			location,
+			key,
+			personGroupId,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Update a Person
 //
 // personGroupId :
@@ -372,9 +925,8 @@ func (c *Client) UpdatePerson(
 	name string,
 	userData string,
 ) (err error) {
-	return cognitive.FaceUpdatePerson(
-		c.Location,
-		c.ApiKey,
+	return c.UpdatePersonWithContext(
+		context.Background(),
 		personGroupId,
 		personId,
 		name,
@@ -382,6 +934,29 @@ func (c *Client) UpdatePerson(
 	)
 }
 
+// Update a Person (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) UpdatePersonWithContext(
+	ctx context.Context,
+	personGroupId string,
+	personId string,
+	name string,
+	userData string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceUpdatePerson(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			personId,
+			name,
+			userData,
+		)
+	})
+}
+
 // Update a Person Face
 //
 // personGroupId   :
@@ -394,9 +969,8 @@ func (c *Client) UpdatePersonFace(
 	persistedFaceId string,
 	userData string,
 ) (err error) {
-	return cognitive.FaceUpdatePersonFace(
-		c.Location,
-		c.ApiKey,
+	return c.UpdatePersonFaceWithContext(
+		context.Background(),
 		personGroupId,
 		personId,
 		persistedFaceId,
@@ -404,6 +978,29 @@ func (c *Client) UpdatePersonFace(
 	)
 }
 
+// Update a Person Face (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) UpdatePersonFaceWithContext(
+	ctx context.Context,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+	userData string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceUpdatePersonFace(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			personId,
+			persistedFaceId,
+			userData,
+		)
+	})
+}
+
 // Create a Person Group
 //
 // personGroupId :
@@ -414,83 +1011,191 @@ func (c *Client) CreatePersonGroup(
 	name string,
 	userData string,
 ) (err error) {
-	return cognitive.FaceCreatePersonGroup(
-		c.Location,
-		c.ApiKey,
+	return c.CreatePersonGroupWithContext(
+		context.Background(),
 		personGroupId,
 		name,
 		userData,
 	)
 }
 
+// Create a Person Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) CreatePersonGroupWithContext(
+	ctx context.Context,
+	personGroupId string,
+	name string,
+	userData string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceCreatePersonGroup(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			name,
+			userData,
+		)
+	})
+}
+
 // Delete a Person Group
 //
 // personGroupId   :
 func (c *Client) DeletePersonGroup(
 	personGroupId string,
 ) (err error) {
-	return cognitive.FaceDeletePersonGroup(
-		c.Location,
-		c.ApiKey,
+	return c.DeletePersonGroupWithContext(
+		context.Background(),
 		personGroupId,
 	)
 }
 
+// Delete a Person Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) DeletePersonGroupWithContext(
+	ctx context.Context,
+	personGroupId string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceDeletePersonGroup(
+			ctx,
+			location,
+			key,
+			personGroupId,
+		)
+	})
+}
+
 // Get a Person Group
 //
 // personGroupId :
 func (c *Client) GetPersonGroup(
 	personGroupId string,
 ) (processResult cognitive.FaceGetPersonGroupResult, err error) {
-	return cognitive.FaceGetPersonGroup(
-		c.Location,
-		c.ApiKey,
+	return c.GetPersonGroupWithContext(
+		context.Background(),
 		personGroupId,
 	)
 }
 
+// Get a Person Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetPersonGroupWithContext(
+	ctx context.Context,
+	personGroupId string,
+) (processResult cognitive.FaceGetPersonGroupResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetPersonGroup(
+			ctx,
+			location,
+			key,
+			personGroupId,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Get Person Group Training Status
 //
 // personGroupId :
 func (c *Client) GetPersonGroupTrainingStatus(
 	personGroupId string,
 ) (processResult cognitive.FaceGetPersonGroupTrainingStatusResult, err error) {
-	return cognitive.FaceGetPersonGroupTrainingStatus(
-		c.Location,
-		c.ApiKey,
+	return c.GetPersonGroupTrainingStatusWithContext(
+		context.Background(),
 		personGroupId,
 	)
 }
 
+// Get Person Group Training Status (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetPersonGroupTrainingStatusWithContext(
+	ctx context.Context,
+	personGroupId string,
+) (processResult cognitive.FaceGetPersonGroupTrainingStatusResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetPersonGroupTrainingStatus(
+			ctx,
+			location,
+			key,
+			personGroupId,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // List Person Groups
 //
 // start    : 0 - 64 characters
-// top      : 1 - 1000 (default 1000)
+// top      : 1 - 1000, or 0 for default (1000)
 func (c *Client) GetPersonGroups(
 	start string,
 	top int,
 ) (processResult []cognitive.FaceGetPersonGroupsResult, err error) {
-	return cognitive.FaceGetPersonGroups(
-		c.Location,
-		c.ApiKey,
+	return c.GetPersonGroupsWithContext(
+		context.Background(),
 		start,
 		top,
 	)
 }
 
+// List Person Groups (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) GetPersonGroupsWithContext(
+	ctx context.Context,
+	start string,
+	top int,
+) (processResult []cognitive.FaceGetPersonGroupsResult, err error) {
+	err = c.withKey(ctx, func(location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().FaceGetPersonGroups(
+			ctx,
+			location,
+			key,
+			start,
+			top,
+		)
+		return err
+	})
+	return processResult, err
+}
+
 // Train Person Group
 //
 // personGroupId :
 func (c *Client) TrainPersonGroup(
 	personGroupId string,
 ) (err error) {
-	return cognitive.FaceTrainPersonGroup(
-		c.Location,
-		c.ApiKey,
+	return c.TrainPersonGroupWithContext(
+		context.Background(),
 		personGroupId,
 	)
 }
 
+// Train Person Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) TrainPersonGroupWithContext(
+	ctx context.Context,
+	personGroupId string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceTrainPersonGroup(
+			ctx,
+			location,
+			key,
+			personGroupId,
+		)
+	})
+}
+
 // Update a Person Group
 //
 // personGroupId :
@@ -499,11 +1204,31 @@ func (c *Client) UpdatePersonGroup(
 	name string,
 	userData string,
 ) (err error) {
-	return cognitive.FaceUpdatePersonGroup(
-		c.Location,
-		c.ApiKey,
+	return c.UpdatePersonGroupWithContext(
+		context.Background(),
 		personGroupId,
 		name,
 		userData,
 	)
 }
+
+// Update a Person Group (with context)
+//
+// ctx : cancels the request when done
+func (c *Client) UpdatePersonGroupWithContext(
+	ctx context.Context,
+	personGroupId string,
+	name string,
+	userData string,
+) (err error) {
+	return c.withKey(ctx, func(location cognitive.ApiLocation, key string) error {
+		return c.api().FaceUpdatePersonGroup(
+			ctx,
+			location,
+			key,
+			personGroupId,
+			name,
+			userData,
+		)
+	})
+}
@@ -3,39 +3,194 @@ package video
 // Wrapper Client for Video functions
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/meinside/ms-cognitive-services-go"
 )
 
 type Client struct {
-	ApiKey string
+	Location  cognitive.ApiLocation
+	ApiKey    string
+	Cognitive *cognitive.Client // client for sending requests (default: cognitive.DefaultClient)
+
+	Keys cognitive.KeyProvider // provider of subscription keys, with failover and rotation (nil: Location and ApiKey)
 }
 
 func NewClient(apiKey string) *Client {
 	return &Client{
-		ApiKey: apiKey,
+		Location: cognitive.WestUS,
+		ApiKey:   apiKey,
+	}
+}
+
+func NewClientWithLocation(location cognitive.ApiLocation, apiKey string) *Client {
+	return &Client{
+		Location: location,
+		ApiKey:   apiKey,
+	}
+}
+
+func NewClientWithEndpoint(endpoint cognitive.EndpointUrl, apiKey string) *Client {
+	return &Client{
+		ApiKey:    apiKey,
+		Cognitive: &cognitive.Client{Endpoint: endpoint},
+	}
+}
+
+func NewClientWithKeys(keys cognitive.KeyProvider) *Client {
+	return &Client{
+		Keys: keys,
+	}
+}
+
+func NewClientWithCredential(location cognitive.ApiLocation, credential cognitive.Credential) *Client {
+	return &Client{
+		Location:  location,
+		Cognitive: &cognitive.Client{Credential: credential},
+	}
+}
+
+// client for sending requests
+func (c *Client) api() *cognitive.Client {
+	if c.Cognitive != nil {
+		return c.Cognitive
+	}
+	return cognitive.DefaultClient
+}
+
+// call fn with the location and subscription key of the client, or with the keys of Keys in order
+func (c *Client) withKey(ctx context.Context, fn func(location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeys(ctx, c.Keys, fn)
 	}
+	return fn(c.Location, c.ApiKey)
+}
+
+// call fn like withKey(), for requests with media (see cognitive.WithKeysForMedia())
+func (c *Client) withKeyForMedia(ctx context.Context, media interface{}, fn func(ctx context.Context, location cognitive.ApiLocation, key string) error) error {
+	if c.Keys != nil {
+		return cognitive.WithKeysForMedia(ctx, c.Keys, media, fn)
+	}
+	return fn(ctx, c.Location, c.ApiKey)
+}
+
+// options of motion detection, from its parameters
+func motionDetectOptions(
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]cognitive.Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+) (opts []cognitive.Option) {
+	opts = []cognitive.Option{
+		cognitive.WithSensitivity(cognitive.SensitivityLevel(sensitivityLevel)),
+		cognitive.WithFrameSamplingValue(frameSamplingValue),
+		cognitive.WithDetectionZones(detectionZones...),
+		cognitive.WithMergeTimeThreshold(mergeTimeThreshold),
+	}
+	if detectLightChange {
+		opts = append(opts, cognitive.WithDetectLightChange())
+	}
+	return opts
+}
+
+// options of thumbnail, from its parameters
+func thumbnailOptions(
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+) (opts []cognitive.Option) {
+	opts = []cognitive.Option{
+		cognitive.WithMaxThumbnailDuration(maxMotionThumbnailDurationInSecs),
+	}
+	if !outputAudio {
+		opts = append(opts, cognitive.WithoutAudio())
+	}
+	if !fadeInFadeOut {
+		opts = append(opts, cognitive.WithoutFadeInFadeOut())
+	}
+	return opts
 }
 
 // Face Detection and Tracking
 //
-// video            : string(video url) or []byte(video bytes array)
+// video            : string(video url), []byte(video bytes array), cognitive.File(video file path), or io.Reader(video stream)
 // progressNotifier : can be nil
 func (c *Client) FaceDetectTrack(
 	video interface{},
 	progressNotifier func(status string, progress float32),
 ) (processResult cognitive.VideoProcessingResult1, err error) {
-	return cognitive.VideoFaceDetectTrack(
-		c.ApiKey,
+	return c.FaceDetectTrackWithContext(
+		context.Background(),
 		video,
 		progressNotifier,
 	)
 }
 
+// Face Detection and Tracking (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func (c *Client) FaceDetectTrackWithContext(
+	ctx context.Context,
+	video interface{},
+	progressNotifier func(status string, progress float32),
+) (processResult cognitive.VideoProcessingResult1, err error) {
+	return c.FaceDetectTrackWithOptions(
+		ctx,
+		video,
+		cognitive.WithProgress(progressNotifier),
+	)
+}
+
+// Face Detection and Tracking (with options)
+//
+// ctx  : cancels the request and the polling for its result when done
+// opts : cognitive.WithProgress()
+func (c *Client) FaceDetectTrackWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.VideoProcessingResult1, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().VideoFaceDetectTrackWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Face Detection and Tracking (submit)
+//
+// ctx : cancels the request when done
+//
+// submits the video and returns its operation, without waiting for the result
+func (c *Client) FaceDetectTrackSubmit(
+	ctx context.Context,
+	video interface{},
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().VideoFaceDetectTrackSubmitWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+		)
+		return err
+	})
+	return op, err
+}
+
 // Motion Detection
 //
-// video              : string(video url) or []byte(video bytes array)
+// video              : string(video url), []byte(video bytes array), cognitive.File(video file path), or io.Reader(video stream)
 // sensitivityLevel   : "low", "medium", or "high" (default: "medium")
-// frameSamplingValue : 1 ~ 20 (default: 1)
+// frameSamplingValue : 1 ~ 20, or 0 for default (1)
 // detectionZones     : can be nil
 // detectLightChange  : default false
 // mergeTimeThreshold : 0.0 ~ 10.0 (default: 0.0)
@@ -49,8 +204,8 @@ func (c *Client) MotionDetect(
 	mergeTimeThreshold float64,
 	progressNotifier func(status string, progress float32),
 ) (processResult cognitive.VideoProcessingResult2, err error) {
-	return cognitive.VideoMotionDetect(
-		c.ApiKey,
+	return c.MotionDetectWithContext(
+		context.Background(),
 		video,
 		sensitivityLevel,
 		frameSamplingValue,
@@ -61,24 +216,170 @@ func (c *Client) MotionDetect(
 	)
 }
 
+// Motion Detection (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func (c *Client) MotionDetectWithContext(
+	ctx context.Context,
+	video interface{},
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]cognitive.Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+	progressNotifier func(status string, progress float32),
+) (processResult cognitive.VideoProcessingResult2, err error) {
+	return c.MotionDetectWithOptions(
+		ctx,
+		video,
+		append(
+			motionDetectOptions(sensitivityLevel, frameSamplingValue, detectionZones, detectLightChange, mergeTimeThreshold),
+			cognitive.WithProgress(progressNotifier),
+		)...,
+	)
+}
+
+// Motion Detection (with options)
+//
+// ctx  : cancels the request and the polling for its result when done
+// opts : cognitive.WithSensitivity(), cognitive.WithFrameSamplingValue(), cognitive.WithDetectionZones(), cognitive.WithDetectLightChange(), cognitive.WithMergeTimeThreshold(), cognitive.WithProgress()
+func (c *Client) MotionDetectWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (processResult cognitive.VideoProcessingResult2, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		processResult, err = c.api().VideoMotionDetectWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return processResult, err
+}
+
+// Motion Detection (submit)
+//
+// ctx : cancels the request when done
+//
+// submits the video and returns its operation, without waiting for the result
+func (c *Client) MotionDetectSubmit(
+	ctx context.Context,
+	video interface{},
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]cognitive.Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+) (op *cognitive.Operation, err error) {
+	return c.MotionDetectSubmitWithOptions(
+		ctx,
+		video,
+		motionDetectOptions(sensitivityLevel, frameSamplingValue, detectionZones, detectLightChange, mergeTimeThreshold)...,
+	)
+}
+
+// Motion Detection (submit with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithSensitivity(), cognitive.WithFrameSamplingValue(), cognitive.WithDetectionZones(), cognitive.WithDetectLightChange(), cognitive.WithMergeTimeThreshold()
+func (c *Client) MotionDetectSubmitWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().VideoMotionDetectSubmitWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return op, err
+}
+
 // Stabilization
 //
-// video            : string(video url) or []byte(video bytes array)
+// video            : string(video url), []byte(video bytes array), cognitive.File(video file path), or io.Reader(video stream)
 // progressNotifier : can be nil
 func (c *Client) Stabilize(
 	video interface{},
 	progressNotifier func(status string, progress float32),
 ) (fileUrl string, err error) {
-	return cognitive.VideoStabilize(
-		c.ApiKey,
+	return c.StabilizeWithContext(
+		context.Background(),
 		video,
 		progressNotifier,
 	)
 }
 
+// Stabilization (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func (c *Client) StabilizeWithContext(
+	ctx context.Context,
+	video interface{},
+	progressNotifier func(status string, progress float32),
+) (fileUrl string, err error) {
+	return c.StabilizeWithOptions(
+		ctx,
+		video,
+		cognitive.WithProgress(progressNotifier),
+	)
+}
+
+// Stabilization (with options)
+//
+// ctx  : cancels the request and the polling for its result when done
+// opts : cognitive.WithProgress()
+func (c *Client) StabilizeWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (fileUrl string, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		fileUrl, err = c.api().VideoStabilizeWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return fileUrl, err
+}
+
+// Stabilization (submit)
+//
+// ctx : cancels the request when done
+//
+// submits the video and returns its operation, without waiting for the result
+func (c *Client) StabilizeSubmit(
+	ctx context.Context,
+	video interface{},
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().VideoStabilizeSubmitWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+		)
+		return err
+	})
+	return op, err
+}
+
 // Thumbnail
 //
-// video                            : string(video url) or []byte(video bytes array)
+// video                            : string(video url), []byte(video bytes array), cognitive.File(video file path), or io.Reader(video stream)
 // maxMotionThumbnailDurationInSecs : default 0
 // outputAudio                      : default true
 // fadeInFadeOut                    : default true
@@ -90,8 +391,8 @@ func (c *Client) Thumbnail(
 	fadeInFadeOut bool,
 	progressNotifier func(status string, progress float32),
 ) (fileUrl string, err error) {
-	return cognitive.VideoThumbnail(
-		c.ApiKey,
+	return c.ThumbnailWithContext(
+		context.Background(),
 		video,
 		maxMotionThumbnailDurationInSecs,
 		outputAudio,
@@ -99,3 +400,102 @@ func (c *Client) Thumbnail(
 		progressNotifier,
 	)
 }
+
+// Thumbnail (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func (c *Client) ThumbnailWithContext(
+	ctx context.Context,
+	video interface{},
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+	progressNotifier func(status string, progress float32),
+) (fileUrl string, err error) {
+	return c.ThumbnailWithOptions(
+		ctx,
+		video,
+		append(
+			thumbnailOptions(maxMotionThumbnailDurationInSecs, outputAudio, fadeInFadeOut),
+			cognitive.WithProgress(progressNotifier),
+		)...,
+	)
+}
+
+// Thumbnail (with options)
+//
+// ctx  : cancels the request and the polling for its result when done
+// opts : cognitive.WithMaxThumbnailDuration(), cognitive.WithoutAudio(), cognitive.WithoutFadeInFadeOut(), cognitive.WithProgress()
+func (c *Client) ThumbnailWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (fileUrl string, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		fileUrl, err = c.api().VideoThumbnailWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return fileUrl, err
+}
+
+// Thumbnail (submit)
+//
+// ctx : cancels the request when done
+//
+// submits the video and returns its operation, without waiting for the result
+func (c *Client) ThumbnailSubmit(
+	ctx context.Context,
+	video interface{},
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+) (op *cognitive.Operation, err error) {
+	return c.ThumbnailSubmitWithOptions(
+		ctx,
+		video,
+		thumbnailOptions(maxMotionThumbnailDurationInSecs, outputAudio, fadeInFadeOut)...,
+	)
+}
+
+// Thumbnail (submit with options)
+//
+// ctx  : cancels the request when done
+// opts : cognitive.WithMaxThumbnailDuration(), cognitive.WithoutAudio(), cognitive.WithoutFadeInFadeOut()
+func (c *Client) ThumbnailSubmitWithOptions(
+	ctx context.Context,
+	video interface{},
+	opts ...cognitive.Option,
+) (op *cognitive.Operation, err error) {
+	err = c.withKeyForMedia(ctx, video, func(ctx context.Context, location cognitive.ApiLocation, key string) (err error) {
+		op, err = c.api().VideoThumbnailSubmitWithOptions(
+			ctx,
+			location,
+			key,
+			video,
+			opts...,
+		)
+		return err
+	})
+	return op, err
+}
+
+// Resume an operation (eg. submitted before the worker restarted)
+//
+// handle : handle of a video operation, stored from Operation.Handle() or its json
+func (c *Client) Resume(
+	handle cognitive.OperationHandle,
+) (op *cognitive.Operation, err error) {
+	if handle.Kind.Service() != cognitive.ServiceVideo {
+		return nil, fmt.Errorf("Not a video operation: %s", handle.Kind)
+	}
+	if c.Keys != nil {
+		return c.api().ResumeOperationWithKeys(c.Keys, handle)
+	}
+	return c.api().ResumeOperation(c.Location, c.ApiKey, handle)
+}
@@ -2,14 +2,15 @@ package cognitive
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -54,6 +55,7 @@ const (
 	WaitSeconds = 10
 )
 
+// region of the APIs
 type ApiLocation string
 
 const (
@@ -62,11 +64,84 @@ const (
 	WestCentralUS ApiLocation = "westcentralus"
 	WestEurope    ApiLocation = "westeurope"
 	SoutheastAsia ApiLocation = "southeastasia"
+
+	WestUS2            ApiLocation = "westus2"
+	WestUS3            ApiLocation = "westus3"
+	EastUS             ApiLocation = "eastus"
+	CentralUS          ApiLocation = "centralus"
+	NorthCentralUS     ApiLocation = "northcentralus"
+	SouthCentralUS     ApiLocation = "southcentralus"
+	CanadaCentral      ApiLocation = "canadacentral"
+	BrazilSouth        ApiLocation = "brazilsouth"
+	NorthEurope        ApiLocation = "northeurope"
+	UKSouth            ApiLocation = "uksouth"
+	FranceCentral      ApiLocation = "francecentral"
+	GermanyWestCentral ApiLocation = "germanywestcentral"
+	SwitzerlandNorth   ApiLocation = "switzerlandnorth"
+	NorwayEast         ApiLocation = "norwayeast"
+	SwedenCentral      ApiLocation = "swedencentral"
+	EastAsia           ApiLocation = "eastasia"
+	JapanEast          ApiLocation = "japaneast"
+	JapanWest          ApiLocation = "japanwest"
+	KoreaCentral       ApiLocation = "koreacentral"
+	AustraliaEast      ApiLocation = "australiaeast"
+	CentralIndia       ApiLocation = "centralindia"
+	SouthAfricaNorth   ApiLocation = "southafricanorth"
+	UAENorth           ApiLocation = "uaenorth"
 )
 
-// for showing verbose messages
+// for showing verbose messages (with log package, when Client.Logger is not set)
+//
+// Deprecated: set Client.Logger instead.
 var IsVerbose bool = false
 
+// Client for sending requests to the APIs
+type Client struct {
+	HttpClient  *http.Client       // http client for sending requests (default: http.DefaultClient)
+	BaseUrl     string             // if set, replaces "https://LOCATION.api.cognitive.microsoft.com" in all API urls
+	Endpoint    EndpointUrl        // if set, used instead of the locations passed to the APIs (eg. a custom subdomain from ParseEndpoint())
+	UserAgent   string             // value of User-Agent header (can be empty)
+	Headers     map[string]string  // http headers added to every request (can be nil)
+	Retry       *RetryPolicy       // policy for retrying failed requests (nil: no retry)
+	PollOptions *PollOptions       // default options for polling long-running operations (nil: default values)
+	Preprocess  *PreprocessOptions // options for preprocessing images before uploading them (nil: no preprocessing)
+	Logger      Logger             // logger for requests and responses (nil: no logging)
+	LogOptions  *LogOptions        // options for logging (nil: default values)
+	Middlewares []Middleware       // middlewares applied to every request in order, outside of the retry policy (can be nil)
+
+	Instrumentation Instrumentation // instrumentation of API operations, eg. tracing and metrics (nil: no instrumentation)
+	Usage           *UsageRecorder  // recorder of transactions per subscription key and operation (nil: not recorded)
+	Credential      Credential      // credential for authenticating requests, eg. *TokenCredential (nil: subscription keys passed to the APIs)
+
+	limiters     map[rateLimiterKey]*RateLimiter
+	limitersLock sync.RWMutex
+}
+
+// default client, used by the package-level functions
+var DefaultClient = &Client{}
+
+// generate an API url for given location and path
+func (c *Client) apiUrl(location ApiLocation, path string) string {
+	if c.BaseUrl != "" {
+		return strings.TrimRight(c.BaseUrl, "/") + path
+	}
+	if c.Endpoint != "" {
+		return c.Endpoint.BaseUrl() + path
+	}
+	if location == "" {
+		location = WestUS
+	}
+	return location.BaseUrl() + path
+}
+
+// http client for sending requests
+func (c *Client) httpClient() *http.Client {
+	if c.HttpClient != nil {
+		return c.HttpClient
+	}
+	return http.DefaultClient
+}
+
 // for testing only
 var testKeys map[string]string = nil
 
@@ -89,23 +164,39 @@ func initTestKeys() {
 		}
 	}
 
+	// live tests will be skipped without the config file
 	if testKeys == nil {
-		panic("Failed to read config file for testing.")
+		testKeys = map[string]string{}
 	}
 }
 
 // http request with bytes
-func httpRequest(method, url, key string, headers, params map[string]string, data []byte, contentType string) (response *http.Response, err error) {
+func (c *Client) httpRequest(ctx context.Context, method, url, key string, headers, params map[string]string, data []byte, contentType string) (response *http.Response, err error) {
+	if contentType == "" && len(data) > 0 {
+		contentType = http.DetectContentType(data)
+	}
+	return c.httpRequestStream(ctx, method, url, key, headers, params, bytes.NewReader(data), int64(len(data)), contentType)
+}
+
+// http request with a stream (size: negative if unknown)
+func (c *Client) httpRequestStream(ctx context.Context, method, url, key string, headers, params map[string]string, body io.Reader, size int64, contentType string) (response *http.Response, err error) {
 	var req *http.Request
-	if req, err = http.NewRequest(strings.ToUpper(method), url, bytes.NewBuffer(data)); err == nil {
+	if req, err = http.NewRequestWithContext(ctx, strings.ToUpper(method), url, nil); err == nil {
+		setRequestBody(req, body, size)
+
 		// http headers
-		if contentType == "" && len(data) > 0 {
-			contentType = http.DetectContentType(data)
-		}
 		if contentType != "" {
 			req.Header.Set("Content-Type", contentType)
 		}
-		req.Header.Set("Ocp-Apim-Subscription-Key", key)
+		if c.UserAgent != "" {
+			req.Header.Set("User-Agent", c.UserAgent)
+		}
+		for k, v := range c.Headers { // default http headers
+			req.Header.Set(k, v)
+		}
+		if err = c.credential(key).Authorize(req); err != nil {
+			return nil, err
+		}
 		for k, v := range headers { // additional http headers
 			req.Header.Set(k, v)
 		}
@@ -117,242 +208,166 @@ func httpRequest(method, url, key string, headers, params map[string]string, dat
 		}
 		req.URL.RawQuery = query.Encode()
 
-		client := &http.Client{}
-		return client.Do(req)
+		return c.do(req)
 	}
 	return nil, err
 }
 
+// send http request through the chain of middlewares
+func (c *Client) do(req *http.Request) (resp *http.Response, err error) {
+	return c.roundTrip()(req)
+}
+
 // http request with a json object
-func requestJson(method, url, key string, headers, params map[string]string, object interface{}) (response *http.Response, err error) {
+func (c *Client) requestJson(ctx context.Context, method, url, key string, headers, params map[string]string, object interface{}) (response *http.Response, err error) {
 	var data []byte
 	if data, err = json.Marshal(object); err == nil {
-		return httpRequest(method, url, key, headers, params, data, "application/json")
+		c.logRequestBody(ctx, method, url, data)
+
+		return c.httpRequest(ctx, method, url, key, headers, params, data, "application/json")
 	}
 	return nil, err
 }
 
 // http get
-func httpGet(url, key string, params map[string]string) (result []byte, err error) {
+func (c *Client) httpGet(ctx context.Context, url, key string, params map[string]string) (result []byte, err error) {
 	var resp *http.Response
-	if resp, err = httpRequest("get", url, key, nil, params, nil, ""); err == nil {
-		defer resp.Body.Close()
-
-		if result, err = ioutil.ReadAll(resp.Body); err == nil {
-			if IsVerbose {
-				log.Printf("<< request: %s, %+v", url, params)
-				log.Printf(">> response: %s", string(result))
-			}
-
-			if resp.StatusCode == 200 {
-				return result, nil
-			} else {
-				var errResp ApiResponseError
-				if err = json.Unmarshal(result, &errResp); err == nil {
-					if errResp.StatusCode > 0 {
-						err = fmt.Errorf("HTTP %d; %s", errResp.StatusCode, errResp.Message)
-					} else {
-						var resp ApiResponse
-						if err = json.Unmarshal(result, &resp); err == nil {
-							err = fmt.Errorf("%s; %s", resp.Error.Code, resp.Error.Message)
-						}
-					}
-				}
-			}
-		}
+	if resp, err = c.httpRequest(ctx, "get", url, key, nil, params, nil, ""); err == nil {
+		return c.readResponse(resp)
 	}
 	return []byte{}, err
 }
 
 // http post with an object
-func httpPost(url, key string, params map[string]string, object interface{}) (result []byte, err error) {
+func (c *Client) httpPost(ctx context.Context, url, key string, params map[string]string, object interface{}) (result []byte, err error) {
 	var resp *http.Response
-	if resp, err = requestJson("post", url, key, nil, params, object); err == nil {
-		defer resp.Body.Close()
-
-		if result, err = ioutil.ReadAll(resp.Body); err == nil {
-			if IsVerbose {
-				log.Printf("<< request: %s, %+v, %+v", url, params, object)
-				log.Printf(">> response: %s", string(result))
-			}
-
-			if resp.StatusCode == 200 {
-				return result, nil
-			} else if resp.StatusCode == 202 {
-				location := resp.Header.Get("Operation-Location")
-
-				if IsVerbose {
-					log.Printf(">> operation location: %s", location)
-				}
-
-				return []byte(location), nil
-			} else {
-				var errResp ApiResponseError
-				if err = json.Unmarshal(result, &errResp); err == nil {
-					if errResp.StatusCode > 0 {
-						err = fmt.Errorf("HTTP %d; %s", errResp.StatusCode, errResp.Message)
-					} else {
-						var resp ApiResponse
-						if err = json.Unmarshal(result, &resp); err == nil {
-							err = fmt.Errorf("%s; %s", resp.Error.Code, resp.Error.Message)
-						}
-					}
-				}
-			}
-		}
+	if resp, err = c.requestJson(ctx, "post", url, key, nil, params, object); err == nil {
+		return c.readResponse(resp)
 	}
 	return []byte{}, err
 }
 
 // http post with a bytes array
-func httpPostBytes(url, key string, params map[string]string, bts []byte) (result []byte, err error) {
+func (c *Client) httpPostBytes(ctx context.Context, url, key string, params map[string]string, bts []byte) (result []byte, err error) {
 	var resp *http.Response
-	if resp, err = httpRequest("post", url, key, nil, params, bts, "application/octet-stream"); err == nil {
-		defer resp.Body.Close()
+	if resp, err = c.httpRequest(ctx, "post", url, key, nil, params, bts, "application/octet-stream"); err == nil {
+		return c.readResponse(resp)
+	}
+	return []byte{}, err
+}
 
-		if result, err = ioutil.ReadAll(resp.Body); err == nil {
-			if IsVerbose {
-				log.Printf("<< request: %s, %+v, %d bytes", url, params, len(bts))
-				log.Printf(">> response: %s", string(result))
-			}
+// validate given image/video argument (Media, string(url), []byte, File, or io.Reader) and post it
+func (c *Client) postArg(ctx context.Context, url, key string, params map[string]string, arg interface{}, kind MediaKind) (result []byte, err error) {
+	var media Media
+	if media, err = mediaOf(arg); err == nil {
+		if err = media.validate(mediaLimitOf(url, kind), kind); err == nil {
+			return c.postMedia(ctx, url, key, params, media)
+		}
+	}
+	return []byte{}, err
+}
 
-			if resp.StatusCode == 200 {
-				return result, nil
-			} else if resp.StatusCode == 202 {
-				location := resp.Header.Get("Operation-Location")
+// preprocess given image argument with the client's PreprocessOptions, and post it
+//
+// returns the transform for mapping coordinates in the result back to the original image (nil if not preprocessed)
+func (c *Client) postImage(ctx context.Context, url, key string, params map[string]string, arg interface{}) (result []byte, transform *imageTransform, err error) {
+	if arg, transform, err = c.preprocessImage(url, arg); err == nil {
+		if result, err = c.postArg(ctx, url, key, params, arg, MediaImage); err == nil {
+			return result, transform, nil
+		}
+	}
+	return []byte{}, nil, err
+}
 
-				if IsVerbose {
-					log.Printf(">> operation location: %s", location)
-				}
+// read the body of given response, and handle it
+func (c *Client) readResponse(resp *http.Response) (result []byte, err error) {
+	defer resp.Body.Close()
 
-				return []byte(location), nil
-			} else {
-				var errResp ApiResponseError
-				if err = json.Unmarshal(result, &errResp); err == nil {
-					if errResp.StatusCode > 0 {
-						err = fmt.Errorf("HTTP %d; %s", errResp.StatusCode, errResp.Message)
-					} else {
-						var resp ApiResponse
-						if err = json.Unmarshal(result, &resp); err == nil {
-							err = fmt.Errorf("%s; %s", resp.Error.Code, resp.Error.Message)
-						}
-					}
-				}
-			}
-		}
+	if result, err = ioutil.ReadAll(resp.Body); err == nil {
+		c.logResponseBody(resp, result)
+
+		return handleResponse(resp, result)
 	}
 	return []byte{}, err
 }
 
-func postArg(url, key string, params map[string]string, arg interface{}) (result []byte, err error) {
-	switch arg.(type) {
-	case string: // => url
-		if a, ok := arg.(string); ok {
-			result, err = httpPost(
-				url,
-				key,
-				params,
-				struct {
-					Url string `json:"url"`
-				}{Url: a},
-			)
-		} else {
-			err = fmt.Errorf("Could not convert given parameter to string")
-		}
-	case []byte: // => bytes array
-		if a, ok := arg.([]byte); ok {
-			result, err = httpPostBytes(
-				url,
-				key,
-				params,
-				a,
-			)
-		} else {
-			err = fmt.Errorf("Could not convert given parameter to []byte")
-		}
-	default:
-		err = fmt.Errorf("Given parameter type (%T) is not supported", arg)
+// handle http response: returns its body (HTTP 200), operation location (HTTP 202), or an *ApiError
+func handleResponse(resp *http.Response, body []byte) (result []byte, err error) {
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return body, nil
+	case http.StatusAccepted:
+		return []byte(resp.Header.Get("Operation-Location")), nil
 	}
-	return
+	return []byte{}, newApiError(resp, body)
 }
 
 // http put with an object
-func httpPut(url, key string, params map[string]string, object interface{}) (result []byte, err error) {
-	return httpMethod("put", url, key, params, object)
+func (c *Client) httpPut(ctx context.Context, url, key string, params map[string]string, object interface{}) (result []byte, err error) {
+	return c.httpMethod(ctx, "put", url, key, params, object)
 }
 
 // http delete
-func httpDelete(url, key string, params map[string]string) (result []byte, err error) {
-	return httpMethod("delete", url, key, params, nil)
+func (c *Client) httpDelete(ctx context.Context, url, key string, params map[string]string) (result []byte, err error) {
+	return c.httpMethod(ctx, "delete", url, key, params, nil)
 }
 
 // http patch
-func httpPatch(url, key string, params map[string]string, object interface{}) (result []byte, err error) {
-	return httpMethod("patch", url, key, params, object)
+func (c *Client) httpPatch(ctx context.Context, url, key string, params map[string]string, object interface{}) (result []byte, err error) {
+	return c.httpMethod(ctx, "patch", url, key, params, object)
 }
 
-func httpMethod(method, url, key string, params map[string]string, object interface{}) (result []byte, err error) {
+func (c *Client) httpMethod(ctx context.Context, method, url, key string, params map[string]string, object interface{}) (result []byte, err error) {
 	var resp *http.Response
-
-	if IsVerbose {
-		switch object.(type) {
-		case []byte:
-			if b, ok := object.([]byte); ok {
-				log.Printf("<< request (HTTP %s): %s, %+v, %d bytes", method, url, params, len(b))
-			}
-		default:
-			log.Printf("<< request (HTTP %s): %s, %+v, %+v", method, url, params, object)
-		}
-	}
-
-	if resp, err = requestJson(method, url, key, nil, params, object); err == nil {
-		defer resp.Body.Close()
-
-		if result, err = ioutil.ReadAll(resp.Body); err == nil {
-			if IsVerbose {
-				log.Printf(">> response (HTTP %d): %s", resp.StatusCode, string(result))
-			}
-
-			if resp.StatusCode == 200 {
-				return result, nil
-			} else if resp.StatusCode == 202 {
-				location := resp.Header.Get("Operation-Location")
-
-				if IsVerbose {
-					log.Printf(">> operation location: %s", location)
-				}
-
-				return []byte(location), nil
-			} else {
-				var errResp ApiResponseError
-				if err = json.Unmarshal(result, &errResp); err == nil {
-					if errResp.StatusCode > 0 {
-						err = fmt.Errorf("HTTP %d; %s", errResp.StatusCode, errResp.Message)
-					} else {
-						var resp ApiResponse
-						if err = json.Unmarshal(result, &resp); err == nil {
-							err = fmt.Errorf("%s; %s", resp.Error.Code, resp.Error.Message)
-						}
-					}
-				}
-			}
-		}
+	if resp, err = c.requestJson(ctx, method, url, key, nil, params, object); err == nil {
+		return c.readResponse(resp)
 	}
 	return []byte{}, err
 }
 
 // download a file from given url
 func Download(url, key, localPath string) (err error) {
+	return DownloadWithContext(context.Background(), url, key, localPath)
+}
+
+// download a file from given url (with context)
+func DownloadWithContext(ctx context.Context, url, key, localPath string) (err error) {
+	return DefaultClient.Download(ctx, url, key, localPath)
+}
+
+// download a file from given url (with client)
+func (c *Client) Download(ctx context.Context, url, key, localPath string) (err error) {
 	var out *os.File
 	if out, err = os.Create(localPath); err == nil {
 		defer out.Close()
 
 		var resp *http.Response
-		if resp, err = httpRequest("get", url, key, nil, nil, nil, ""); err == nil {
+		if resp, err = c.httpRequest(withOperationName(ctx, "Download"), "get", url, key, nil, nil, nil, ""); err == nil {
 			defer resp.Body.Close()
 
+			if resp.StatusCode != http.StatusOK {
+				var body []byte
+				if body, err = ioutil.ReadAll(resp.Body); err == nil {
+					err = newApiError(resp, body)
+				}
+				return err
+			}
+
 			_, err = io.Copy(out, resp.Body)
 		}
 	}
 	return err
 }
+
+// wait for given duration, or until ctx is done
+func sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
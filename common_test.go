@@ -0,0 +1,129 @@
+package cognitive
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// tests which send requests to the APIs, with the keys they need
+var liveTests = map[string]string{
+	"computervision-subscription-key": "^TestComputerVision(AnalyzeImage|DescribeImage|GetThumbnail|GetModels|DomainSpecificRecognize|Ocr|RecognizeHandwritten|TagImage)$",
+	"emotion-subscription-key":        "^TestEmotion(Image|Video)$",
+	"face-subscription-key":           "^TestFace(_List|_Person_PersonGroup)?$",
+	"video-subscription-key":          "^TestVideo(FaceDetectTrack|MotionDetect|Stabilize|Thumbnail)$",
+}
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	// skip tests which send requests to the APIs, if their keys are not set in the config file
+	skips := []string{}
+	for key, pattern := range liveTests {
+		if testKeys[key] == "" {
+			skips = append(skips, pattern)
+		}
+	}
+	if len(skips) > 0 {
+		sort.Strings(skips)
+		if skip := flag.Lookup("test.skip").Value.String(); skip != "" {
+			skips = append(skips, skip)
+		}
+		flag.Set("test.skip", strings.Join(skips, "|"))
+
+		log.Printf("Skipping tests without keys in %s: %s", confFilenameForTest, strings.Join(skips, "|"))
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestDownloadWithContext(t *testing.T) {
+	// a server which never responds until the request is cancelled
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "cognitive")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	if err := DownloadWithContext(ctx, server.URL, "", filepath.Join(dir, "download")); err == nil {
+		t.Errorf("DownloadWithContext() should fail with a cancelled context")
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Errorf("DownloadWithContext() was not aborted in time: %s", elapsed)
+	}
+}
+
+func TestSleepWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := sleep(ctx, WaitSeconds*time.Second); err != context.Canceled {
+		t.Errorf("sleep() should return context.Canceled, got: %v", err)
+	}
+}
+
+func TestClientWithBaseUrl(t *testing.T) {
+	// a stand-in server for Face API
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/face/v1.0/detect" {
+			t.Errorf("Unexpected path: %s", r.URL.Path)
+		}
+		if ua := r.Header.Get("User-Agent"); ua != "cognitive-test" {
+			t.Errorf("Unexpected User-Agent: %s", ua)
+		}
+		if h := r.Header.Get("X-Test-Header"); h != "test" {
+			t.Errorf("Unexpected X-Test-Header: %s", h)
+		}
+		if key := r.Header.Get("Ocp-Apim-Subscription-Key"); key != "test-key" {
+			t.Errorf("Unexpected subscription key: %s", key)
+		}
+		if body, _ := io.ReadAll(r.Body); string(body) != `{"url":"https://example.com/face.jpg"}` {
+			t.Errorf("Unexpected body: %s", string(body))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{"faceId":"face-1","faceRectangle":{"left":1,"top":2,"width":3,"height":4}}]`)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HttpClient: server.Client(),
+		BaseUrl:    server.URL + "/",
+		UserAgent:  "cognitive-test",
+		Headers:    map[string]string{"X-Test-Header": "test"},
+	}
+
+	if result, err := client.FaceDetect(
+		context.Background(),
+		WestUS,
+		"test-key",
+		"https://example.com/face.jpg",
+		true,
+		false,
+		nil,
+	); err == nil {
+		if len(result) != 1 || result[0].FaceId != "face-1" || result[0].FaceRectangle.Height != 4 {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	} else {
+		t.Errorf("FaceDetect() failed: %s", err)
+	}
+}
@@ -1,12 +1,10 @@
 package cognitive
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"log"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type ComputerVisionImageAnalyzeResult struct {
@@ -149,7 +147,7 @@ type ComputerVisionTagImageResult struct {
 //
 // location       : API location
 // key            : subscription key for this API
-// image          : string(image url) or []byte(image bytes array)
+// image          : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // visualFeatures : "Categories", "Tags", "Description", "Faces", "ImageType", "Color", "Adult"
 // details        : "Celebrities", "Landmarks"
 // language       : "en" or "zh" (default: "en")
@@ -161,25 +159,138 @@ func ComputerVisionAnalyzeImage(
 	details []string,
 	language string,
 ) (processResult ComputerVisionImageAnalyzeResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/analyze"
+	return ComputerVisionAnalyzeImageWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		visualFeatures,
+		details,
+		language,
+	)
+}
+
+// Computer Vision API: Analyze Image (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionAnalyzeImageWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	visualFeatures []string,
+	details []string,
+	language string,
+) (processResult ComputerVisionImageAnalyzeResult, err error) {
+	return DefaultClient.ComputerVisionAnalyzeImage(
+		ctx,
+		location,
+		key,
+		image,
+		visualFeatures,
+		details,
+		language,
+	)
+}
+
+// Computer Vision API: Analyze Image (with client)
+//
+// same as ComputerVisionAnalyzeImageWithContext(), but requested through this client
+func (c *Client) ComputerVisionAnalyzeImage(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	visualFeatures []string,
+	details []string,
+	language string,
+) (processResult ComputerVisionImageAnalyzeResult, err error) {
+	return c.ComputerVisionAnalyzeImageWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		func(o *options) {
+			o.visualFeatures = enumsOf[VisualFeature](visualFeatures)
+			o.details = enumsOf[Detail](details)
+			o.language = language
+		},
+	)
+}
+
+// Computer Vision API: Analyze Image (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithVisualFeatures(), WithDetails(), WithLanguage()
+func ComputerVisionAnalyzeImageWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionImageAnalyzeResult, err error) {
+	return DefaultClient.ComputerVisionAnalyzeImageWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Computer Vision API: Analyze Image (with options and client)
+//
+// same as ComputerVisionAnalyzeImageWithOptions(), but requested through this client
+func (c *Client) ComputerVisionAnalyzeImageWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionImageAnalyzeResult, err error) {
+	var o *options
+	if o, err = newOptions(apiComputerVisionAnalyzeImage, opts); err != nil {
+		return ComputerVisionImageAnalyzeResult{}, err
+	}
+	visualFeatures, details, language := o.visualFeatures, o.details, o.language
+
+	if err = validateVisualFeatures(visualFeatures, details); err != nil {
+		return ComputerVisionImageAnalyzeResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/vision/v1.0/analyze")
 
 	// params
 	params := map[string]string{}
 	if len(visualFeatures) > 0 {
-		params["visualFeatures"] = strings.Join(visualFeatures, ",")
+		features := []string{}
+		for _, feature := range visualFeatures {
+			features = append(features, feature.String())
+		}
+		params["visualFeatures"] = strings.Join(features, ",")
 	}
 	if len(details) > 0 {
-		params["details"] = strings.Join(details, ",")
+		names := []string{}
+		for _, detail := range details {
+			names = append(names, detail.String())
+		}
+		params["details"] = strings.Join(names, ",")
 	}
 	if language != "" {
 		params["language"] = language
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	var transform *imageTransform
+	result, transform, err = c.postImage(ctx, apiUrl, key, params, image)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
+			processResult.mapCoordinates(transform)
+
 			return processResult, nil
 		}
 	}
@@ -192,7 +303,7 @@ func ComputerVisionAnalyzeImage(
 //
 // location      : API location
 // key           : subscription key for this API
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // maxCandidates : default 1
 func ComputerVisionDescribeImage(
 	location ApiLocation,
@@ -200,7 +311,99 @@ func ComputerVisionDescribeImage(
 	image interface{},
 	maxCandidates int,
 ) (processResult ComputerVisionImageDescribeResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/describe"
+	return ComputerVisionDescribeImageWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		maxCandidates,
+	)
+}
+
+// Computer Vision API: Describe Image (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionDescribeImageWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	maxCandidates int,
+) (processResult ComputerVisionImageDescribeResult, err error) {
+	return DefaultClient.ComputerVisionDescribeImage(
+		ctx,
+		location,
+		key,
+		image,
+		maxCandidates,
+	)
+}
+
+// Computer Vision API: Describe Image (with client)
+//
+// same as ComputerVisionDescribeImageWithContext(), but requested through this client
+func (c *Client) ComputerVisionDescribeImage(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	maxCandidates int,
+) (processResult ComputerVisionImageDescribeResult, err error) {
+	return c.ComputerVisionDescribeImageWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		func(o *options) {
+			o.maxCandidates = maxCandidates
+		},
+	)
+}
+
+// Computer Vision API: Describe Image (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithMaxCandidates()
+func ComputerVisionDescribeImageWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionImageDescribeResult, err error) {
+	return DefaultClient.ComputerVisionDescribeImageWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Computer Vision API: Describe Image (with options and client)
+//
+// same as ComputerVisionDescribeImageWithOptions(), but requested through this client
+func (c *Client) ComputerVisionDescribeImageWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionImageDescribeResult, err error) {
+	var o *options
+	if o, err = newOptions(apiComputerVisionDescribeImage, opts); err != nil {
+		return ComputerVisionImageDescribeResult{}, err
+	}
+	maxCandidates := o.maxCandidates
+
+	if err = validateNonNegative("maxCandidates", maxCandidates); err != nil {
+		return ComputerVisionImageDescribeResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/vision/v1.0/describe")
 
 	// params
 	params := map[string]string{}
@@ -209,10 +412,13 @@ func ComputerVisionDescribeImage(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	var transform *imageTransform
+	result, transform, err = c.postImage(ctx, apiUrl, key, params, image)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
+			processResult.mapCoordinates(transform)
+
 			return processResult, nil
 		}
 	}
@@ -225,7 +431,7 @@ func ComputerVisionDescribeImage(
 //
 // location      : API location
 // key           : subscription key for this API
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // width         : 1 ~ 1024 (recommended: minimum 50)
 // height        : 1 ~ 1024 (recommended: minimum 50)
 // smartCropping :
@@ -237,13 +443,123 @@ func ComputerVisionGetThumbnail(
 	height int,
 	smartCropping bool,
 ) (processResult []byte, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/generateThumbnail"
+	return ComputerVisionGetThumbnailWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		width,
+		height,
+		smartCropping,
+	)
+}
+
+// Computer Vision API: Get Thumbnail (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionGetThumbnailWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	width int,
+	height int,
+	smartCropping bool,
+) (processResult []byte, err error) {
+	return DefaultClient.ComputerVisionGetThumbnail(
+		ctx,
+		location,
+		key,
+		image,
+		width,
+		height,
+		smartCropping,
+	)
+}
+
+// Computer Vision API: Get Thumbnail (with client)
+//
+// same as ComputerVisionGetThumbnailWithContext(), but requested through this client
+func (c *Client) ComputerVisionGetThumbnail(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	width int,
+	height int,
+	smartCropping bool,
+) (processResult []byte, err error) {
+	return c.ComputerVisionGetThumbnailWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		width,
+		height,
+		func(o *options) {
+			o.smartCropping = smartCropping
+		},
+	)
+}
+
+// Computer Vision API: Get Thumbnail (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// width    : 1 ~ 1024 (recommended: minimum 50)
+// height   : 1 ~ 1024 (recommended: minimum 50)
+// opts     : WithSmartCropping()
+func ComputerVisionGetThumbnailWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	width int,
+	height int,
+	opts ...Option,
+) (processResult []byte, err error) {
+	return DefaultClient.ComputerVisionGetThumbnailWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		width,
+		height,
+		opts...,
+	)
+}
+
+// Computer Vision API: Get Thumbnail (with options and client)
+//
+// same as ComputerVisionGetThumbnailWithOptions(), but requested through this client
+func (c *Client) ComputerVisionGetThumbnailWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	width int,
+	height int,
+	opts ...Option,
+) (processResult []byte, err error) {
+	var o *options
+	if o, err = newOptions(apiComputerVisionGetThumbnail, opts); err != nil {
+		return []byte{}, err
+	}
+	smartCropping := o.smartCropping
+
+	if err = firstError(
+		validateRange("width", width, 1, 1024),
+		validateRange("height", height, 1, 1024),
+	); err != nil {
+		return []byte{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/vision/v1.0/generateThumbnail")
 
 	// params
 	params := map[string]string{}
-	if width < 1 || width > 1024 || height < 1 || height > 1024 {
-		return []byte{}, fmt.Errorf("Parameter width and height should be in range: 1 - 1024")
-	}
 	params["width"] = strconv.Itoa(width)
 	params["height"] = strconv.Itoa(height)
 	if smartCropping {
@@ -251,7 +567,7 @@ func ComputerVisionGetThumbnail(
 	}
 
 	var result []byte
-	if result, err = postArg(apiUrl, key, params, image); err == nil {
+	if result, _, err = c.postImage(ctx, apiUrl, key, params, image); err == nil {
 		return result, nil
 	}
 	return []byte{}, err
@@ -267,10 +583,40 @@ func ComputerVisionGetModels(
 	location ApiLocation,
 	key string,
 ) (processResult ComputerVisionDomainSpecificModelsResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/models"
+	return ComputerVisionGetModelsWithContext(
+		context.Background(),
+		location,
+		key,
+	)
+}
+
+// Computer Vision API: List Domain Specific Models (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionGetModelsWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+) (processResult ComputerVisionDomainSpecificModelsResult, err error) {
+	return DefaultClient.ComputerVisionGetModels(
+		ctx,
+		location,
+		key,
+	)
+}
+
+// Computer Vision API: List Domain Specific Models (with client)
+//
+// same as ComputerVisionGetModelsWithContext(), but requested through this client
+func (c *Client) ComputerVisionGetModels(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+) (processResult ComputerVisionDomainSpecificModelsResult, err error) {
+	apiUrl := c.apiUrl(location, "/vision/v1.0/models")
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -286,7 +632,7 @@ func ComputerVisionGetModels(
 //
 // location          : API location
 // key               : subscription key for this API
-// image             : string(image url) or []byte(image bytes array)
+// image             : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // language          : "unk", "zh-Hans", "zh-Hant", "cs", "da", "nl", "en", "fi", "fr", "de", "el", "hu", "it", "ja", "ko", "nb", "pl", "pt", "ru", "es", "sv", "tr" (default: "unk")
 // detectOrientation :
 func ComputerVisionOcr(
@@ -296,7 +642,100 @@ func ComputerVisionOcr(
 	language string,
 	detectOrientation bool,
 ) (processResult ComputerVisionOcrResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/ocr"
+	return ComputerVisionOcrWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		language,
+		detectOrientation,
+	)
+}
+
+// Computer Vision API: OCR (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionOcrWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	language string,
+	detectOrientation bool,
+) (processResult ComputerVisionOcrResult, err error) {
+	return DefaultClient.ComputerVisionOcr(
+		ctx,
+		location,
+		key,
+		image,
+		language,
+		detectOrientation,
+	)
+}
+
+// Computer Vision API: OCR (with client)
+//
+// same as ComputerVisionOcrWithContext(), but requested through this client
+func (c *Client) ComputerVisionOcr(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	language string,
+	detectOrientation bool,
+) (processResult ComputerVisionOcrResult, err error) {
+	return c.ComputerVisionOcrWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		func(o *options) {
+			o.language = language
+			o.detectOrientation = detectOrientation
+		},
+	)
+}
+
+// Computer Vision API: OCR (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithLanguage(), WithDetectOrientation()
+func ComputerVisionOcrWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionOcrResult, err error) {
+	return DefaultClient.ComputerVisionOcrWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Computer Vision API: OCR (with options and client)
+//
+// same as ComputerVisionOcrWithOptions(), but requested through this client
+func (c *Client) ComputerVisionOcrWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionOcrResult, err error) {
+	var o *options
+	if o, err = newOptions(apiComputerVisionOcr, opts); err != nil {
+		return ComputerVisionOcrResult{}, err
+	}
+	language, detectOrientation := o.language, o.detectOrientation
+
+	apiUrl := c.apiUrl(location, "/vision/v1.0/ocr")
 
 	// params
 	params := map[string]string{}
@@ -308,10 +747,13 @@ func ComputerVisionOcr(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	var transform *imageTransform
+	result, transform, err = c.postImage(ctx, apiUrl, key, params, image)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
+			processResult.mapCoordinates(transform)
+
 			return processResult, nil
 		}
 	}
@@ -324,7 +766,7 @@ func ComputerVisionOcr(
 //
 // location : API location
 // key      : subscription key for this API
-// image    : string(image url) or []byte(image bytes array)
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // model    :
 func ComputerVisionDomainSpecificRecognize(
 	location ApiLocation,
@@ -332,7 +774,45 @@ func ComputerVisionDomainSpecificRecognize(
 	image interface{},
 	model string,
 ) (processResult ComputerVisionDomainSpecificResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/models/" + model + "/analyze"
+	return ComputerVisionDomainSpecificRecognizeWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		model,
+	)
+}
+
+// Computer Vision API: Recognize Domain Specific Content (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionDomainSpecificRecognizeWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	model string,
+) (processResult ComputerVisionDomainSpecificResult, err error) {
+	return DefaultClient.ComputerVisionDomainSpecificRecognize(
+		ctx,
+		location,
+		key,
+		image,
+		model,
+	)
+}
+
+// Computer Vision API: Recognize Domain Specific Content (with client)
+//
+// same as ComputerVisionDomainSpecificRecognizeWithContext(), but requested through this client
+func (c *Client) ComputerVisionDomainSpecificRecognize(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	model string,
+) (processResult ComputerVisionDomainSpecificResult, err error) {
+	apiUrl := c.apiUrl(location, "/vision/v1.0/models/"+model+"/analyze")
 
 	// params
 	params := map[string]string{
@@ -340,10 +820,13 @@ func ComputerVisionDomainSpecificRecognize(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	var transform *imageTransform
+	result, transform, err = c.postImage(ctx, apiUrl, key, params, image)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
+			processResult.mapCoordinates(transform)
+
 			return processResult, nil
 		}
 	}
@@ -354,8 +837,9 @@ func ComputerVisionDomainSpecificRecognize(
 //
 // https://westus.dev.cognitive.microsoft.com/docs/services/56f91f2d778daf23d8ec6739/operations/587f2c6a154055056008f200
 //
+// location         : API location
 // key              : subscription key for this API
-// image            : string(image url) or []byte(image bytes array)
+// image            : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // handwriting      : (default: false)
 // progressNotifier : can be nil
 func ComputerVisionRecognizeHandwritten(
@@ -365,7 +849,212 @@ func ComputerVisionRecognizeHandwritten(
 	handwriting bool,
 	progressNotifier func(status string, progress float32),
 ) (processResult ComputerVisionHandwrittenProcessingResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/recognizeText"
+	return ComputerVisionRecognizeHandwrittenWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		handwriting,
+		progressNotifier,
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func ComputerVisionRecognizeHandwrittenWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	handwriting bool,
+	progressNotifier func(status string, progress float32),
+) (processResult ComputerVisionHandwrittenProcessingResult, err error) {
+	return DefaultClient.ComputerVisionRecognizeHandwritten(
+		ctx,
+		location,
+		key,
+		image,
+		handwriting,
+		progressNotifier,
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (with client)
+//
+// same as ComputerVisionRecognizeHandwrittenWithContext(), but requested through this client
+func (c *Client) ComputerVisionRecognizeHandwritten(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	handwriting bool,
+	progressNotifier func(status string, progress float32),
+) (processResult ComputerVisionHandwrittenProcessingResult, err error) {
+	return c.ComputerVisionRecognizeHandwrittenWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		func(o *options) {
+			o.handwriting = handwriting
+			o.progressNotifier = progressNotifier
+		},
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (with options)
+//
+// ctx      : cancels the request and the polling for its result when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithHandwriting(), WithProgress()
+func ComputerVisionRecognizeHandwrittenWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionHandwrittenProcessingResult, err error) {
+	return DefaultClient.ComputerVisionRecognizeHandwrittenWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (with options and client)
+//
+// same as ComputerVisionRecognizeHandwrittenWithOptions(), but requested through this client
+func (c *Client) ComputerVisionRecognizeHandwrittenWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult ComputerVisionHandwrittenProcessingResult, err error) {
+	var o *options
+	if o, err = newOptions(apiComputerVisionRecognizeHandwritten, opts); err != nil {
+		return ComputerVisionHandwrittenProcessingResult{}, err
+	}
+
+	var op *Operation
+	if op, err = c.computerVisionRecognizeHandwrittenSubmit(
+		ctx,
+		location,
+		key,
+		image,
+		o,
+	); err == nil {
+		if err = op.waitResult(ctx, o.progressNotifier, &processResult); err == nil {
+			return processResult, nil
+		}
+	}
+	return ComputerVisionHandwrittenProcessingResult{}, err
+}
+
+// Computer Vision API: Recognize Handwritten Text (submit)
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/56f91f2d778daf23d8ec6739/operations/587f2c6a154055056008f200
+//
+// ctx         : cancels the request when done
+// location    : API location
+// key         : subscription key for this API
+// image       : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// handwriting : (default: false)
+//
+// submits the image and returns its operation, without waiting for the result
+func ComputerVisionRecognizeHandwrittenSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	handwriting bool,
+) (op *Operation, err error) {
+	return DefaultClient.ComputerVisionRecognizeHandwrittenSubmit(
+		ctx,
+		location,
+		key,
+		image,
+		handwriting,
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (submit with client)
+//
+// same as ComputerVisionRecognizeHandwrittenSubmit(), but requested through this client
+func (c *Client) ComputerVisionRecognizeHandwrittenSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	handwriting bool,
+) (op *Operation, err error) {
+	return c.ComputerVisionRecognizeHandwrittenSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		func(o *options) {
+			o.handwriting = handwriting
+		},
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (submit) (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithHandwriting()
+func ComputerVisionRecognizeHandwrittenSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	return DefaultClient.ComputerVisionRecognizeHandwrittenSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Computer Vision API: Recognize Handwritten Text (submit) (with options and client)
+//
+// same as ComputerVisionRecognizeHandwrittenSubmitWithOptions(), but requested through this client
+func (c *Client) ComputerVisionRecognizeHandwrittenSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	var o *options
+	if o, err = newOptions(apiComputerVisionRecognizeHandwrittenSubmit, opts); err != nil {
+		return nil, err
+	}
+	return c.computerVisionRecognizeHandwrittenSubmit(ctx, location, key, image, o)
+}
+
+// submit with given options (for ComputerVisionRecognizeHandwrittenSubmitWithOptions() and ComputerVisionRecognizeHandwrittenWithOptions())
+func (c *Client) computerVisionRecognizeHandwrittenSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	o *options,
+) (op *Operation, err error) {
+	handwriting := o.handwriting
+
+	apiUrl := c.apiUrl(location, "/vision/v1.0/recognizeText")
 
 	params := map[string]string{}
 	if handwriting {
@@ -373,57 +1062,14 @@ func ComputerVisionRecognizeHandwritten(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
-
-	// get operation result
-	//
-	// https://westus.dev.cognitive.microsoft.com/docs/services/56f91f2d778daf23d8ec6739/operations/587f2cf1154055056008f201
-	if err == nil {
-		opLocation := string(result)
-
-		if IsVerbose {
-			log.Printf("<< Checking operation location...: %s", opLocation)
-		}
-
-		var lastProgress float32 = -1.0
-
-		for i := 0; i < NumTries; i++ {
-			time.Sleep(WaitSeconds * time.Second)
-
-			var status struct {
-				Status            string                                    `json:"status"`
-				Progress          float32                                   `json:"progress"`
-				Message           string                                    `json:"message"`
-				RecognitionResult ComputerVisionHandwrittenProcessingResult `json:"recognitionResult"`
-			}
-			if result, err = httpGet(opLocation, key, nil); err == nil {
-				if err = json.Unmarshal(result, &status); err == nil {
-					if status.Status == "Succeeded" {
-						return status.RecognitionResult, nil
-					} else if status.Status == "Failed" {
-						err = fmt.Errorf(status.Message)
-						break
-					} else {
-						if progressNotifier != nil && lastProgress != status.Progress {
-							progressNotifier(status.Status, status.Progress)
-						}
-
-						if IsVerbose {
-							log.Printf(">> %s (%.2f%%)", status.Status, status.Progress)
-						}
-
-						lastProgress = status.Progress
-					}
-				}
-			}
-
-			if i+1 == NumTries {
-				err = fmt.Errorf("Reached the limit of tries: %d", NumTries)
-			}
+	var transform *imageTransform
+	if result, transform, err = c.postImage(ctx, apiUrl, key, params, image); err == nil {
+		if op, err = c.submittedOperation(OperationComputerVisionRecognizeHandwritten, result, key); err == nil {
+			op.transform = transform
 		}
+		return op, err
 	}
-
-	return ComputerVisionHandwrittenProcessingResult{}, err
+	return nil, err
 }
 
 // Computer Vision API: Tag Image
@@ -432,19 +1078,56 @@ func ComputerVisionRecognizeHandwritten(
 //
 // location : API location
 // key      : subscription key for this API
-// image    : string(image url) or []byte(image bytes array)
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 func ComputerVisionTagImage(
 	location ApiLocation,
 	key string,
 	image interface{},
 ) (processResult ComputerVisionTagImageResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/vision/v1.0/tag"
+	return ComputerVisionTagImageWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+	)
+}
+
+// Computer Vision API: Tag Image (with context)
+//
+// ctx : cancels the request when done
+func ComputerVisionTagImageWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+) (processResult ComputerVisionTagImageResult, err error) {
+	return DefaultClient.ComputerVisionTagImage(
+		ctx,
+		location,
+		key,
+		image,
+	)
+}
+
+// Computer Vision API: Tag Image (with client)
+//
+// same as ComputerVisionTagImageWithContext(), but requested through this client
+func (c *Client) ComputerVisionTagImage(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+) (processResult ComputerVisionTagImageResult, err error) {
+	apiUrl := c.apiUrl(location, "/vision/v1.0/tag")
 
 	var result []byte
-	result, err = postArg(apiUrl, key, nil, image)
+	var transform *imageTransform
+	result, transform, err = c.postImage(ctx, apiUrl, key, nil, image)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
+			processResult.mapCoordinates(transform)
+
 			return processResult, nil
 		}
 	}
@@ -0,0 +1,147 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// credential which authenticates requests
+//
+// can be set to Client.Credential, which overrides subscription keys passed to the APIs
+type Credential interface {
+	Authorize(req *http.Request) error
+}
+
+// credential with a subscription key (Ocp-Apim-Subscription-Key header)
+type KeyCredential string
+
+// set the subscription key to given request (implements Credential)
+func (k KeyCredential) Authorize(req *http.Request) error {
+	req.Header.Set("Ocp-Apim-Subscription-Key", string(k))
+	return nil
+}
+
+// access token of Azure AD
+type Token struct {
+	AccessToken string
+	ExpiresOn   time.Time // (zero: never expires)
+}
+
+// source of access tokens (eg. a wrapper of azidentity credentials)
+type TokenSource interface {
+	Token(ctx context.Context) (Token, error)
+}
+
+// function which implements TokenSource
+type TokenSourceFunc func(ctx context.Context) (Token, error)
+
+// get a new token (implements TokenSource)
+func (f TokenSourceFunc) Token(ctx context.Context) (Token, error) {
+	return f(ctx)
+}
+
+// default values of TokenCredential
+const (
+	DefaultTokenRefreshBefore = 5 * time.Minute
+)
+
+// errors returned from TokenCredential
+var (
+	ErrEmptyToken = errors.New("empty access token")
+)
+
+// credential with Azure AD access tokens (Authorization: Bearer header), safe for concurrent use
+//
+// tokens are cached, and refreshed from the source before they expire
+type TokenCredential struct {
+	Source        TokenSource
+	RefreshBefore time.Duration // refresh tokens this long before they expire (default: DefaultTokenRefreshBefore)
+
+	now func() time.Time
+
+	mu    sync.Mutex
+	token Token
+}
+
+// create a new token credential with given token source
+func NewTokenCredential(source TokenSource) *TokenCredential {
+	return &TokenCredential{
+		Source: source,
+	}
+}
+
+// set a bearer token to given request (implements Credential)
+func (c *TokenCredential) Authorize(req *http.Request) error {
+	token, err := c.Token(req.Context())
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+	return nil
+}
+
+// get the cached token, or a new one from the source if it is about to expire
+func (c *TokenCredential) Token(ctx context.Context) (token Token, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.token.AccessToken != "" && !c.expiresSoon(c.token) {
+		return c.token, nil
+	}
+
+	if token, err = c.Source.Token(ctx); err == nil {
+		if token.AccessToken == "" {
+			return Token{}, ErrEmptyToken
+		}
+		c.token = token
+	}
+	return token, err
+}
+
+// discard the cached token, so that a new one is fetched for the next request
+func (c *TokenCredential) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.token = Token{}
+}
+
+// check if given token expires soon
+func (c *TokenCredential) expiresSoon(token Token) bool {
+	if token.ExpiresOn.IsZero() {
+		return false
+	}
+
+	refreshBefore := c.RefreshBefore
+	if refreshBefore <= 0 {
+		refreshBefore = DefaultTokenRefreshBefore
+	}
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	return !now().Add(refreshBefore).Before(token.ExpiresOn)
+}
+
+// credential for requests with given subscription key
+func (c *Client) credential(key string) Credential {
+	if c.Credential != nil {
+		return c.Credential
+	}
+	return KeyCredential(key)
+}
+
+// identity of the resource which given request is authenticated for, used as the key of rate limiters and usages
+//
+// returns the subscription key of the request, or the host of its url (eg. "my-resource.cognitiveservices.azure.com")
+// if it has no subscription key (eg. authenticated with TokenCredential)
+func resourceIdOf(req *http.Request) (id string, isKey bool) {
+	if key := req.Header.Get("Ocp-Apim-Subscription-Key"); key != "" {
+		return key, true
+	}
+	return strings.ToLower(req.URL.Host), false
+}
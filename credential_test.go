@@ -0,0 +1,127 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTokenCredential(t *testing.T) {
+	var fetched int
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	credential := NewTokenCredential(TokenSourceFunc(func(ctx context.Context) (Token, error) {
+		fetched++
+		return Token{AccessToken: fmt.Sprintf("token-%d", fetched), ExpiresOn: now.Add(time.Hour)}, nil
+	}))
+	credential.now = func() time.Time { return now }
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "" {
+			t.Errorf("Subscription key should not be sent with a token credential")
+		}
+		if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", fetched) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `[]`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL, Credential: credential}
+
+	// cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.FaceGetLists(context.Background(), WestUS, ""); err != nil {
+			t.Errorf("FaceGetLists() failed: %s", err)
+		}
+	}
+	if fetched != 1 {
+		t.Errorf("Token should be cached, fetched %d times", fetched)
+	}
+
+	// refreshed before expiry
+	now = now.Add(56 * time.Minute)
+	if _, err := client.FaceGetLists(context.Background(), WestUS, ""); err != nil || fetched != 2 {
+		t.Errorf("FaceGetLists() = %v, fetched %d times", err, fetched)
+	}
+
+	// invalidated
+	credential.Invalidate()
+	if _, err := client.FaceGetLists(context.Background(), WestUS, ""); err != nil || fetched != 3 {
+		t.Errorf("FaceGetLists() = %v, fetched %d times", err, fetched)
+	}
+
+	// failed to get a token
+	errSource := errors.New("failed to get a token")
+	client.Credential = NewTokenCredential(TokenSourceFunc(func(ctx context.Context) (Token, error) {
+		return Token{}, errSource
+	}))
+	if _, err := client.FaceGetLists(context.Background(), WestUS, ""); !errors.Is(err, errSource) {
+		t.Errorf("FaceGetLists() should fail with the error of token source, got: %v", err)
+	}
+}
+
+func TestKeyCredential(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "credential-key" || r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `[]`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL, Credential: KeyCredential("credential-key")}
+	if _, err := client.FaceGetLists(context.Background(), WestUS, "ignored-key"); err != nil {
+		t.Errorf("FaceGetLists() failed: %s", err)
+	}
+}
+
+func TestTokenCredentialLimitsAndUsages(t *testing.T) {
+	newResource := func() *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, `[]`)
+		}))
+	}
+	resourceA, resourceB := newResource(), newResource()
+	defer resourceA.Close()
+	defer resourceB.Close()
+	hostA, hostB := "westus.api.cognitive.microsoft.com", "westeurope.api.cognitive.microsoft.com"
+
+	usage := NewUsageRecorder(nil)
+	client := &Client{
+		HttpClient: routingHttpClient(map[ApiLocation]*httptest.Server{WestUS: resourceA, WestEurope: resourceB}),
+		Credential: NewTokenCredential(TokenSourceFunc(func(ctx context.Context) (Token, error) {
+			return Token{AccessToken: "token"}, nil
+		})),
+		Usage: usage,
+	}
+	client.SetRateLimiter(ServiceFace, hostA, NewRateLimiter(RateLimit{PerSecond: 1, Mode: RateLimitFail}))
+
+	// limited per resource, not in a single bucket for all tokens
+	if _, err := client.FaceGetLists(context.Background(), WestUS, ""); err != nil {
+		t.Errorf("FaceGetLists() failed: %s", err)
+	}
+	if _, err := client.FaceGetLists(context.Background(), WestUS, ""); !IsRateLimited(err) {
+		t.Errorf("FaceGetLists() should be rate limited, got: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.FaceGetLists(context.Background(), WestEurope, ""); err != nil {
+			t.Errorf("FaceGetLists() failed: %s", err)
+		}
+	}
+
+	// recorded per resource
+	usages := map[string]int64{}
+	for _, u := range usage.Usages() {
+		usages[u.Key] += u.Transactions
+	}
+	if usages[hostA] != 1 || usages[hostB] != 2 || len(usages) != 2 {
+		t.Errorf("Unexpected usages: %+v", usages)
+	}
+}
@@ -1,16 +1,24 @@
 package cognitive
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
-	"time"
 )
 
 type Emotion struct {
 	FaceRectangle Rectangle          `json:"faceRectangle"`
 	Scores        map[string]float64 `json:"scores"`
+	EmotionScores EmotionScores      `json:"-"` // typed Scores
+}
+
+func (e *Emotion) UnmarshalJSON(data []byte) (err error) {
+	type emotion Emotion
+	if err = json.Unmarshal(data, (*emotion)(e)); err == nil {
+		e.EmotionScores = NewEmotionScores(e.Scores)
+	}
+	return err
 }
 
 // https://docs.microsoft.com/en-us/azure/cognitive-services/emotion/emotion-api-how-to-topics/howtocallemotionforvideo
@@ -26,39 +34,136 @@ type EmotionProcessingResult struct {
 		Duration int64 `json:"duration"`
 		Interval int64 `json:"interval"`
 		Events   [][]struct {
-			WindowFaceDistribution map[string]float64 `json:"windowFaceDistribution"`
-			WindowMeanScores       map[string]float64 `json:"windowMeanScores"`
+			WindowFaceDistribution  map[string]float64 `json:"windowFaceDistribution"`
+			WindowMeanScores        map[string]float64 `json:"windowMeanScores"`
+			WindowMeanEmotionScores EmotionScores      `json:"-"` // typed WindowMeanScores
 		} `json:"events"`
 	} `json:"fragments"`
 }
 
+func (r *EmotionProcessingResult) UnmarshalJSON(data []byte) (err error) {
+	type result EmotionProcessingResult
+	if err = json.Unmarshal(data, (*result)(r)); err == nil {
+		for _, fragment := range r.Fragments {
+			for _, events := range fragment.Events {
+				for i := range events {
+					events[i].WindowMeanEmotionScores = NewEmotionScores(events[i].WindowMeanScores)
+				}
+			}
+		}
+	}
+	return err
+}
+
 // Emotion API: Emotion Recognition
 //
 // https://westus.dev.cognitive.microsoft.com/docs/services/5639d931ca73072154c1ce89/operations/563b31ea778daf121cc3a5fa
 // https://westus.dev.cognitive.microsoft.com/docs/services/5639d931ca73072154c1ce89/operations/56f23eb019845524ec61c4d7
 //
 // key   : subscription key for this API
-// image : string(image url) or []byte(image bytes array)
+// image : Media, string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // rects : rectangles of faces (can be nil if none)
+//
+// requested to WestUS (EmotionRecognizeImageWithOptions() for other locations)
 func EmotionRecognizeImage(key string, image interface{}, rects []Rectangle) (emotions []Emotion, err error) {
-	const apiUrl = "https://westus.api.cognitive.microsoft.com/emotion/v1.0/recognize"
+	return EmotionRecognizeImageWithContext(context.Background(), key, image, rects)
+}
+
+// Emotion API: Emotion Recognition (with context)
+//
+// ctx : cancels the request when done
+func EmotionRecognizeImageWithContext(ctx context.Context, key string, image interface{}, rects []Rectangle) (emotions []Emotion, err error) {
+	return DefaultClient.EmotionRecognizeImage(ctx, key, image, rects)
+}
+
+// Emotion API: Emotion Recognition (with client)
+//
+// same as EmotionRecognizeImageWithContext(), but requested through this client
+func (c *Client) EmotionRecognizeImage(
+	ctx context.Context,
+	key string,
+	image interface{},
+	rects []Rectangle,
+) (emotions []Emotion, err error) {
+	return c.EmotionRecognizeImageWithOptions(
+		ctx,
+		WestUS,
+		key,
+		image,
+		func(o *options) {
+			o.rects = rects
+		},
+	)
+}
+
+// Emotion API: Emotion Recognition (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : Media, string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithFaceRectangles()
+func EmotionRecognizeImageWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (emotions []Emotion, err error) {
+	return DefaultClient.EmotionRecognizeImageWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Emotion API: Emotion Recognition (with options and client)
+//
+// same as EmotionRecognizeImageWithOptions(), but requested through this client
+func (c *Client) EmotionRecognizeImageWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (emotions []Emotion, err error) {
+	var o *options
+	if o, err = newOptions(apiEmotionRecognizeImage, opts); err != nil {
+		return []Emotion{}, err
+	}
+	rects := o.rects
+
+	apiUrl := c.apiUrl(location, "/emotion/v1.0/recognize")
 
 	var result []byte
 
+	// preprocess the image, and map the rectangles onto it
+	var transform *imageTransform
+	if image, transform, err = c.preprocessImage(apiUrl, image); err != nil {
+		return []Emotion{}, err
+	}
+
 	var params map[string]string = nil
 	if len(rects) > 0 {
 		faceRects := []string{}
 		for _, rect := range rects {
+			rect = transform.inverseRect(rect)
 			faceRects = append(faceRects, fmt.Sprintf("%d,%d,%d,%d", rect.Left, rect.Top, rect.Width, rect.Height))
 		}
 
 		params = map[string]string{"faceRectangles": strings.Join(faceRects, ";")}
 	}
 
-	result, err = postArg(apiUrl, key, params, image)
+	result, err = c.postArg(ctx, apiUrl, key, params, image, MediaImage)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &emotions); err == nil {
+			for i := range emotions {
+				emotions[i].mapCoordinates(transform)
+			}
+
 			return emotions, nil
 		}
 	}
@@ -70,73 +175,231 @@ func EmotionRecognizeImage(key string, image interface{}, rects []Rectangle) (em
 // https://westus.dev.cognitive.microsoft.com/docs/services/5639d931ca73072154c1ce89/operations/56f8d40e1984551ec0a0984e
 //
 // key              : subscription key for this API
-// video            : string(video url) or []byte(video bytes array)
+// video            : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
 // outputStyle      : "aggregate" (default) or "perFrame"
 // progressNotifier : can be nil
+//
+// requested to WestUS (EmotionRecognizeVideoWithOptions() for other locations)
 func EmotionRecognizeVideo(
 	key string,
 	video interface{},
 	outputStyle string,
 	progressNotifier func(status string, progress float32),
 ) (processResult EmotionProcessingResult, err error) {
-	const apiUrl = "https://westus.api.cognitive.microsoft.com/emotion/v1.0/recognizeinvideo"
+	return EmotionRecognizeVideoWithContext(
+		context.Background(),
+		key,
+		video,
+		outputStyle,
+		progressNotifier,
+	)
+}
 
-	var result []byte
+// Emotion API: Emotion Recognition in Video (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func EmotionRecognizeVideoWithContext(
+	ctx context.Context,
+	key string,
+	video interface{},
+	outputStyle string,
+	progressNotifier func(status string, progress float32),
+) (processResult EmotionProcessingResult, err error) {
+	return DefaultClient.EmotionRecognizeVideo(
+		ctx,
+		key,
+		video,
+		outputStyle,
+		progressNotifier,
+	)
+}
 
-	// params
-	params := map[string]string{}
-	if outputStyle != "" {
-		params["outputStyle"] = outputStyle // "aggregate" (default) or "perFrame"
-	}
+// Emotion API: Emotion Recognition in Video (with client)
+//
+// same as EmotionRecognizeVideoWithContext(), but requested through this client
+func (c *Client) EmotionRecognizeVideo(
+	ctx context.Context,
+	key string,
+	video interface{},
+	outputStyle string,
+	progressNotifier func(status string, progress float32),
+) (processResult EmotionProcessingResult, err error) {
+	return c.EmotionRecognizeVideoWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.outputStyle = OutputStyle(outputStyle)
+			o.progressNotifier = progressNotifier
+		},
+	)
+}
 
-	result, err = postArg(apiUrl, key, params, video)
+// Emotion API: Emotion Recognition in Video (with options)
+//
+// ctx      : cancels the request and the polling for its result when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithOutputStyle(), WithProgress()
+func EmotionRecognizeVideoWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (processResult EmotionProcessingResult, err error) {
+	return DefaultClient.EmotionRecognizeVideoWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
 
-	// get recognition in video operation result
-	//
-	// https://westus.dev.cognitive.microsoft.com/docs/services/5639d931ca73072154c1ce89/operations/56f8d4471984551ec0a0984f
-	if err == nil {
-		opLocation := string(result)
+// Emotion API: Emotion Recognition in Video (with options and client)
+//
+// same as EmotionRecognizeVideoWithOptions(), but requested through this client
+func (c *Client) EmotionRecognizeVideoWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (processResult EmotionProcessingResult, err error) {
+	var o *options
+	if o, err = newOptions(apiEmotionRecognizeVideo, opts); err != nil {
+		return EmotionProcessingResult{}, err
+	}
 
-		if IsVerbose {
-			log.Printf("<< Checking operation location...: %s", opLocation)
+	var op *Operation
+	if op, err = c.emotionRecognizeVideoSubmit(
+		ctx,
+		location,
+		key,
+		video,
+		o,
+	); err == nil {
+		if err = op.waitResult(ctx, o.progressNotifier, &processResult); err == nil {
+			return processResult, nil
 		}
+	}
+	return EmotionProcessingResult{}, err
+}
 
-		var lastProgress float32 = -1.0
-
-		for i := 0; i < NumTries; i++ {
-			time.Sleep(WaitSeconds * time.Second)
-
-			var status OperationStatus
-			if result, err = httpGet(opLocation, key, nil); err == nil {
-				if err = json.Unmarshal(result, &status); err == nil {
-					if status.Status == "Succeeded" {
-						if err = json.Unmarshal([]byte(status.ProcessingResultJson), &processResult); err == nil {
-							return processResult, nil
-						} else {
-							break
-						}
-					} else if status.Status == "Failed" {
-						err = fmt.Errorf(status.Message)
-						break
-					} else {
-						if progressNotifier != nil && lastProgress != status.Progress {
-							progressNotifier(status.Status, status.Progress)
-						}
-
-						if IsVerbose {
-							log.Printf(">> %s (%.2f%%)", status.Status, status.Progress)
-						}
-
-						lastProgress = status.Progress
-					}
-				}
-			}
+// Emotion API: Emotion Recognition in Video (submit)
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/5639d931ca73072154c1ce89/operations/56f8d40e1984551ec0a0984e
+//
+// ctx         : cancels the request when done
+// key         : subscription key for this API
+// video       : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// outputStyle : "aggregate" (default) or "perFrame"
+//
+// submits the video and returns its operation, without waiting for the result
+//
+// requested to WestUS (EmotionRecognizeVideoSubmitWithOptions() for other locations)
+func EmotionRecognizeVideoSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+	outputStyle string,
+) (op *Operation, err error) {
+	return DefaultClient.EmotionRecognizeVideoSubmit(
+		ctx,
+		key,
+		video,
+		outputStyle,
+	)
+}
 
-			if i+1 == NumTries {
-				err = fmt.Errorf("Reached the limit of tries: %d", NumTries)
-			}
-		}
+// Emotion API: Emotion Recognition in Video (submit with client)
+//
+// same as EmotionRecognizeVideoSubmit(), but requested through this client
+func (c *Client) EmotionRecognizeVideoSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+	outputStyle string,
+) (op *Operation, err error) {
+	return c.EmotionRecognizeVideoSubmitWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.outputStyle = OutputStyle(outputStyle)
+		},
+	)
+}
+
+// Emotion API: Emotion Recognition in Video (submit) (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithOutputStyle()
+func EmotionRecognizeVideoSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	return DefaultClient.EmotionRecognizeVideoSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Emotion API: Emotion Recognition in Video (submit) (with options and client)
+//
+// same as EmotionRecognizeVideoSubmitWithOptions(), but requested through this client
+func (c *Client) EmotionRecognizeVideoSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	var o *options
+	if o, err = newOptions(apiEmotionRecognizeVideoSubmit, opts); err != nil {
+		return nil, err
 	}
+	return c.emotionRecognizeVideoSubmit(ctx, location, key, video, o)
+}
 
-	return EmotionProcessingResult{}, err
+// submit with given options (for EmotionRecognizeVideoSubmitWithOptions() and EmotionRecognizeVideoWithOptions())
+func (c *Client) emotionRecognizeVideoSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	o *options,
+) (op *Operation, err error) {
+	outputStyle := o.outputStyle
+
+	if outputStyle != "" && !outputStyle.IsValid() {
+		return nil, invalidParameter("outputStyle", "unknown output style: %q", outputStyle)
+	}
+
+	apiUrl := c.apiUrl(location, "/emotion/v1.0/recognizeinvideo")
+
+	// params
+	params := map[string]string{}
+	if outputStyle != "" {
+		params["outputStyle"] = outputStyle.String() // "aggregate" (default) or "perFrame"
+	}
+
+	var result []byte
+	if result, err = c.postArg(ctx, apiUrl, key, params, video, MediaVideo); err == nil {
+		return c.submittedOperation(OperationEmotionRecognizeVideo, result, key)
+	}
+	return nil, err
 }
@@ -0,0 +1,212 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// name of an emotion
+type EmotionName string
+
+const (
+	EmotionAnger     EmotionName = "anger"
+	EmotionContempt  EmotionName = "contempt"
+	EmotionDisgust   EmotionName = "disgust"
+	EmotionFear      EmotionName = "fear"
+	EmotionHappiness EmotionName = "happiness"
+	EmotionNeutral   EmotionName = "neutral"
+	EmotionSadness   EmotionName = "sadness"
+	EmotionSurprise  EmotionName = "surprise"
+)
+
+// all emotion names
+var EmotionNames = []EmotionName{
+	EmotionAnger, EmotionContempt, EmotionDisgust, EmotionFear,
+	EmotionHappiness, EmotionNeutral, EmotionSadness, EmotionSurprise,
+}
+
+func (n EmotionName) String() string {
+	return string(n)
+}
+
+// score of an emotion
+type EmotionScore struct {
+	Name  EmotionName `json:"name"`
+	Score float64     `json:"score"`
+}
+
+// scores of emotions, shared by the results of Emotion API (Emotion, EmotionProcessingResult) and Face API (FaceDetectAttributes.Emotion)
+type EmotionScores struct {
+	Anger     float64 `json:"anger"`
+	Contempt  float64 `json:"contempt"`
+	Disgust   float64 `json:"disgust"`
+	Fear      float64 `json:"fear"`
+	Happiness float64 `json:"happiness"`
+	Neutral   float64 `json:"neutral"`
+	Sadness   float64 `json:"sadness"`
+	Surprise  float64 `json:"surprise"`
+
+	Extra map[string]float64 `json:"-"` // scores of unknown emotions (nil if none)
+}
+
+func (s *EmotionScores) UnmarshalJSON(data []byte) (err error) {
+	var scores map[string]float64
+	if err = json.Unmarshal(data, &scores); err == nil {
+		*s = NewEmotionScores(scores)
+	}
+	return err
+}
+
+func (s EmotionScores) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Map())
+}
+
+// create emotion scores from a map of scores (eg. Emotion.Scores, WindowMeanScores of EmotionProcessingResult)
+//
+// results of Emotion API have them in Emotion.EmotionScores and WindowMeanEmotionScores, when unmarshaled from JSON
+//
+// keys are matched case-insensitively, and unknown ones are kept in Extra
+func NewEmotionScores(scores map[string]float64) (s EmotionScores) {
+	for key, score := range scores {
+		if p := s.pointer(EmotionName(strings.ToLower(key))); p != nil {
+			*p = score
+		} else {
+			if s.Extra == nil {
+				s.Extra = map[string]float64{}
+			}
+			s.Extra[key] = score
+		}
+	}
+	return s
+}
+
+// score of given emotion (looked up in Extra if it is not one of EmotionNames, 0.0 if not found)
+func (s EmotionScores) Score(name EmotionName) float64 {
+	if p := s.pointer(name); p != nil {
+		return *p
+	}
+	return s.Extra[string(name)]
+}
+
+// scores of all emotions, in the order of EmotionNames
+func (s EmotionScores) Scores() []EmotionScore {
+	scores := []EmotionScore{}
+	for _, name := range EmotionNames {
+		scores = append(scores, EmotionScore{Name: name, Score: s.Score(name)})
+	}
+	return scores
+}
+
+// scores in a map, keyed with emotion names (including the unknown ones in Extra)
+func (s EmotionScores) Map() map[string]float64 {
+	scores := map[string]float64{}
+	for key, score := range s.Extra {
+		scores[key] = score
+	}
+	for _, name := range EmotionNames {
+		scores[string(name)] = s.Score(name)
+	}
+	return scores
+}
+
+// emotion with the highest score (the first one in the order of EmotionNames if tied)
+func (s EmotionScores) Dominant() EmotionScore {
+	return s.TopN(1)[0]
+}
+
+// n emotions with the highest scores, in descending order of scores
+func (s EmotionScores) TopN(n int) []EmotionScore {
+	scores := s.Scores()
+	sort.SliceStable(scores, func(i, j int) bool {
+		return scores[i].Score > scores[j].Score
+	})
+
+	if n < 0 {
+		n = 0
+	} else if n > len(scores) {
+		n = len(scores)
+	}
+	return scores[:n]
+}
+
+// sum of all scores
+func (s EmotionScores) Sum() (sum float64) {
+	for _, name := range EmotionNames {
+		sum += s.Score(name)
+	}
+	return sum
+}
+
+// scores scaled so that their sum is 1.0 (returned as they are if the sum is 0.0)
+//
+// scores in Extra are not scaled
+func (s EmotionScores) Normalize() EmotionScores {
+	sum := s.Sum()
+	if sum == 0.0 {
+		return s
+	}
+
+	for _, name := range EmotionNames {
+		*s.pointer(name) /= sum
+	}
+	return s
+}
+
+// pointer to the score of given emotion (nil if unknown)
+func (s *EmotionScores) pointer(name EmotionName) *float64 {
+	switch name {
+	case EmotionAnger:
+		return &s.Anger
+	case EmotionContempt:
+		return &s.Contempt
+	case EmotionDisgust:
+		return &s.Disgust
+	case EmotionFear:
+		return &s.Fear
+	case EmotionHappiness:
+		return &s.Happiness
+	case EmotionNeutral:
+		return &s.Neutral
+	case EmotionSadness:
+		return &s.Sadness
+	case EmotionSurprise:
+		return &s.Surprise
+	}
+	return nil
+}
+
+// emotion scores of each event (window) in all fragments, in order
+//
+// events without windowMeanScores (eg. ones without faces) are skipped
+func (r EmotionProcessingResult) WindowMeanScores() []EmotionScores {
+	scores := []EmotionScores{}
+	for _, fragment := range r.Fragments {
+		for _, events := range fragment.Events {
+			for _, event := range events {
+				if len(event.WindowMeanScores) > 0 {
+					scores = append(scores, event.WindowMeanEmotionScores)
+				}
+			}
+		}
+	}
+	return scores
+}
+
+// mean of emotion scores of all events (windows)
+func (r EmotionProcessingResult) MeanScores() (mean EmotionScores) {
+	windows := r.WindowMeanScores()
+	if len(windows) == 0 {
+		return mean
+	}
+
+	for _, window := range windows {
+		for _, name := range EmotionNames {
+			*mean.pointer(name) += window.Score(name)
+		}
+	}
+	for _, name := range EmotionNames {
+		*mean.pointer(name) /= float64(len(windows))
+	}
+	return mean
+}
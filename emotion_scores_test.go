@@ -0,0 +1,88 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEmotionScores(t *testing.T) {
+	// from Emotion API
+	var emotions []Emotion
+	if err := json.Unmarshal([]byte(`[{"faceRectangle":{"left":1,"top":2,"width":3,"height":4},"scores":{"anger":0.1,"contempt":0.0,"disgust":0.0,"fear":0.0,"happiness":0.6,"neutral":0.2,"sadness":0.0,"surprise":0.1,"boredom":0.5}}]`), &emotions); err != nil {
+		t.Fatalf("Failed to unmarshal emotions: %s", err)
+	}
+	scores := emotions[0].EmotionScores
+	if !reflect.DeepEqual(scores, EmotionScores{Anger: 0.1, Happiness: 0.6, Neutral: 0.2, Surprise: 0.1, Extra: map[string]float64{"boredom": 0.5}}) {
+		t.Errorf("Unexpected scores: %+v", scores)
+	}
+
+	if bytes, err := json.Marshal(emotions[0]); err != nil || strings.Contains(string(bytes), "EmotionScores") {
+		t.Errorf("Typed scores should not be marshaled: %s, %v", bytes, err)
+	}
+
+	// from Face API
+	var attributes FaceDetectAttributes
+	if err := json.Unmarshal([]byte(`{"emotion":{"anger":0.1,"contempt":0.0,"disgust":0.0,"fear":0.0,"happiness":0.6,"neutral":0.2,"sadness":0.0,"surprise":0.1,"boredom":0.5}}`), &attributes); err != nil {
+		t.Fatalf("Failed to unmarshal face attributes: %s", err)
+	}
+	if !reflect.DeepEqual(attributes.Emotion, scores) {
+		t.Errorf("Scores from Face API should be the same: %+v", attributes.Emotion)
+	}
+
+	// dominant, top n
+	if dominant := scores.Dominant(); dominant.Name != EmotionHappiness || dominant.Score != 0.6 {
+		t.Errorf("Unexpected dominant emotion: %+v", dominant)
+	}
+	top := scores.TopN(3)
+	if len(top) != 3 || top[0].Name != EmotionHappiness || top[1].Name != EmotionNeutral || top[2].Name != EmotionAnger {
+		t.Errorf("Unexpected top emotions: %+v", top)
+	}
+	if len(scores.TopN(100)) != len(EmotionNames) || len(scores.TopN(-1)) != 0 {
+		t.Errorf("Unexpected number of top emotions")
+	}
+	if (EmotionScores{}).Dominant().Name != EmotionAnger {
+		t.Errorf("First emotion should be dominant when tied")
+	}
+
+	// normalize
+	normalized := EmotionScores{Happiness: 3, Sadness: 1}.Normalize()
+	if normalized.Happiness != 0.75 || normalized.Sadness != 0.25 || math.Abs(normalized.Sum()-1.0) > 1e-9 {
+		t.Errorf("Unexpected normalized scores: %+v", normalized)
+	}
+	if !reflect.DeepEqual((EmotionScores{}).Normalize(), EmotionScores{}) {
+		t.Errorf("Zero scores should not be normalized")
+	}
+
+	// map
+	if m := scores.Map(); len(m) != len(EmotionNames)+1 || m["happiness"] != 0.6 || m["boredom"] != 0.5 || !reflect.DeepEqual(NewEmotionScores(m), scores) {
+		t.Errorf("Unexpected map: %+v", m)
+	}
+	if scores.Score("boredom") != 0.5 || scores.Score("unknown") != 0.0 {
+		t.Errorf("Unexpected scores of unknown emotions")
+	}
+	if NewEmotionScores(map[string]float64{"Happiness": 1.0}).Happiness != 1.0 {
+		t.Errorf("Keys should be matched case-insensitively")
+	}
+
+	// from Emotion API (video)
+	var result EmotionProcessingResult
+	if err := json.Unmarshal([]byte(`{"version":1,"fragments":[
+		{"start":0,"duration":100,"interval":50,"events":[[{"windowFaceDistribution":{"happiness":1.0},"windowMeanScores":{"happiness":0.8,"neutral":0.2}}],[{}]]},
+		{"start":100,"duration":50,"interval":50,"events":[[{"windowFaceDistribution":{"neutral":1.0},"windowMeanScores":{"happiness":0.2,"neutral":0.6,"sadness":0.2}}]]}
+	]}`), &result); err != nil {
+		t.Fatalf("Failed to unmarshal processing result: %s", err)
+	}
+	if events := result.Fragments[0].Events; !reflect.DeepEqual(events[0][0].WindowMeanEmotionScores, EmotionScores{Happiness: 0.8, Neutral: 0.2}) || !reflect.DeepEqual(events[1][0].WindowMeanEmotionScores, EmotionScores{}) {
+		t.Errorf("Unexpected typed window scores: %+v", events)
+	}
+	windows := result.WindowMeanScores()
+	if len(windows) != 2 || windows[0].Dominant().Name != EmotionHappiness || windows[1].Dominant().Name != EmotionNeutral {
+		t.Errorf("Unexpected window scores: %+v", windows)
+	}
+	if mean := result.MeanScores(); math.Abs(mean.Happiness-0.5) > 1e-9 || math.Abs(mean.Neutral-0.4) > 1e-9 || math.Abs(mean.Sadness-0.1) > 1e-9 {
+		t.Errorf("Unexpected mean scores: %+v", mean)
+	}
+}
@@ -1,8 +1,12 @@
 package cognitive
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -49,3 +53,18 @@ func TestEmotionVideo(t *testing.T) {
 		fmt.Printf("File read error.\n")
 	}
 }
+
+func TestEmotionEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/westeurope/emotion/v1.0/recognize" {
+			t.Errorf("Unexpected request path: %s", r.URL.Path)
+		}
+		io.WriteString(w, `[{"faceRectangle":{"left":1,"top":2,"width":3,"height":4},"scores":{"happiness":0.9}}]`)
+	}))
+	defer server.Close()
+
+	client := &Client{Endpoint: MustParseEndpoint(server.URL + "/westeurope")}
+	if emotions, err := client.EmotionRecognizeImageWithOptions(context.Background(), WestEurope, "test-key", "http://localhost/face.jpg"); err != nil || len(emotions) != 1 {
+		t.Errorf("EmotionRecognizeImageWithOptions() = %+v, %v", emotions, err)
+	}
+}
@@ -0,0 +1,75 @@
+package cognitive
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// all regions of the APIs
+var Locations = []ApiLocation{
+	WestUS, WestUS2, WestUS3, EastUS, EastUS2, CentralUS, NorthCentralUS, SouthCentralUS, WestCentralUS,
+	CanadaCentral, BrazilSouth,
+	NorthEurope, WestEurope, UKSouth, FranceCentral, GermanyWestCentral, SwitzerlandNorth, NorwayEast, SwedenCentral,
+	EastAsia, SoutheastAsia, JapanEast, JapanWest, KoreaCentral, AustraliaEast, CentralIndia,
+	SouthAfricaNorth, UAENorth,
+}
+
+// base url of the region's APIs, without trailing slash (eg. "https://westus.api.cognitive.microsoft.com")
+func (l ApiLocation) BaseUrl() string {
+	return "https://" + string(l) + ".api.cognitive.microsoft.com"
+}
+
+// check if the location is one of Locations
+func (l ApiLocation) IsValid() bool {
+	for _, location := range Locations {
+		if l == location {
+			return true
+		}
+	}
+	return false
+}
+
+// endpoint of the region
+func RegionalEndpoint(location ApiLocation) EndpointUrl {
+	return EndpointUrl(location.BaseUrl())
+}
+
+// endpoint with a url (eg. "https://my-resource.cognitiveservices.azure.com"), for Client.Endpoint
+type EndpointUrl string
+
+// base url of the APIs, without trailing slash
+func (e EndpointUrl) BaseUrl() string {
+	return strings.TrimRight(string(e), "/")
+}
+
+// parse given url as an endpoint
+//
+// (eg. "https://my-resource.cognitiveservices.azure.com", "https://westus.api.cognitive.microsoft.com/", "http://localhost:8080/proxy")
+func ParseEndpoint(rawurl string) (endpoint EndpointUrl, err error) {
+	var u *url.URL
+	if u, err = url.Parse(strings.TrimSpace(rawurl)); err != nil {
+		return "", fmt.Errorf("invalid endpoint %q: %s", rawurl, err)
+	}
+
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("invalid endpoint %q: scheme should be https or http", rawurl)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("invalid endpoint %q: host is missing", rawurl)
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid endpoint %q: user info, query, and fragment are not allowed", rawurl)
+	}
+
+	return EndpointUrl(u.Scheme + "://" + u.Host + strings.TrimRight(u.EscapedPath(), "/")), nil
+}
+
+// parse given url as an endpoint, panics if it is invalid
+func MustParseEndpoint(rawurl string) EndpointUrl {
+	endpoint, err := ParseEndpoint(rawurl)
+	if err != nil {
+		panic(err)
+	}
+	return endpoint
+}
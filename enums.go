@@ -0,0 +1,201 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// convert string values (of the APIs without options) to their typed ones
+func enumsOf[T ~string](values []string) (enums []T) {
+	if values == nil {
+		return nil
+	}
+	enums = make([]T, 0, len(values))
+	for _, value := range values {
+		enums = append(enums, T(value))
+	}
+	return enums
+}
+
+// face attribute returned from Face API: Detect
+type FaceAttribute string
+
+const (
+	FaceAttributeAge        FaceAttribute = "age"
+	FaceAttributeGender     FaceAttribute = "gender"
+	FaceAttributeHeadPose   FaceAttribute = "headPose"
+	FaceAttributeSmile      FaceAttribute = "smile"
+	FaceAttributeFacialHair FaceAttribute = "facialHair"
+	FaceAttributeGlasses    FaceAttribute = "glasses"
+	FaceAttributeEmotion    FaceAttribute = "emotion"
+)
+
+// all face attributes
+var FaceAttributes = []FaceAttribute{
+	FaceAttributeAge, FaceAttributeGender, FaceAttributeHeadPose, FaceAttributeSmile,
+	FaceAttributeFacialHair, FaceAttributeGlasses, FaceAttributeEmotion,
+}
+
+func (a FaceAttribute) String() string {
+	return string(a)
+}
+
+// check if the attribute is one of FaceAttributes
+func (a FaceAttribute) IsValid() bool {
+	for _, attribute := range FaceAttributes {
+		if a == attribute {
+			return true
+		}
+	}
+	return false
+}
+
+// mode of Face API: Find Similar
+type FindSimilarMode string
+
+const (
+	FindSimilarMatchPerson FindSimilarMode = "matchPerson"
+	FindSimilarMatchFace   FindSimilarMode = "matchFace"
+)
+
+func (m FindSimilarMode) String() string {
+	return string(m)
+}
+
+// check if the mode is FindSimilarMatchPerson or FindSimilarMatchFace
+func (m FindSimilarMode) IsValid() bool {
+	return m == FindSimilarMatchPerson || m == FindSimilarMatchFace
+}
+
+// visual feature returned from Computer Vision API: Analyze Image
+type VisualFeature string
+
+const (
+	VisualFeatureCategories  VisualFeature = "Categories"
+	VisualFeatureTags        VisualFeature = "Tags"
+	VisualFeatureDescription VisualFeature = "Description"
+	VisualFeatureFaces       VisualFeature = "Faces"
+	VisualFeatureImageType   VisualFeature = "ImageType"
+	VisualFeatureColor       VisualFeature = "Color"
+	VisualFeatureAdult       VisualFeature = "Adult"
+)
+
+// all visual features
+var VisualFeatures = []VisualFeature{
+	VisualFeatureCategories, VisualFeatureTags, VisualFeatureDescription, VisualFeatureFaces,
+	VisualFeatureImageType, VisualFeatureColor, VisualFeatureAdult,
+}
+
+func (f VisualFeature) String() string {
+	return string(f)
+}
+
+// check if the feature is one of VisualFeatures
+func (f VisualFeature) IsValid() bool {
+	for _, feature := range VisualFeatures {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// domain-specific detail returned from Computer Vision API: Analyze Image
+type Detail string
+
+const (
+	DetailCelebrities Detail = "Celebrities"
+	DetailLandmarks   Detail = "Landmarks"
+)
+
+func (d Detail) String() string {
+	return string(d)
+}
+
+// check if the detail is DetailCelebrities or DetailLandmarks
+func (d Detail) IsValid() bool {
+	return d == DetailCelebrities || d == DetailLandmarks
+}
+
+// output style of Emotion API: Emotion Recognition in Video
+type OutputStyle string
+
+const (
+	OutputStyleAggregate OutputStyle = "aggregate"
+	OutputStylePerFrame  OutputStyle = "perFrame"
+)
+
+func (s OutputStyle) String() string {
+	return string(s)
+}
+
+// check if the style is OutputStyleAggregate or OutputStylePerFrame
+func (s OutputStyle) IsValid() bool {
+	return s == OutputStyleAggregate || s == OutputStylePerFrame
+}
+
+// sensitivity level of Video API: Motion Detection
+type SensitivityLevel string
+
+const (
+	SensitivityLow    SensitivityLevel = "low"
+	SensitivityMedium SensitivityLevel = "medium"
+	SensitivityHigh   SensitivityLevel = "high"
+)
+
+func (l SensitivityLevel) String() string {
+	return string(l)
+}
+
+// check if the level is SensitivityLow, SensitivityMedium, or SensitivityHigh
+func (l SensitivityLevel) IsValid() bool {
+	return l == SensitivityLow || l == SensitivityMedium || l == SensitivityHigh
+}
+
+// status of long-running operations, and of person group trainings
+type ProcessingStatus string
+
+const (
+	StatusNotStarted ProcessingStatus = "NotStarted"
+	StatusUploading  ProcessingStatus = "Uploading"
+	StatusRunning    ProcessingStatus = "Running"
+	StatusSucceeded  ProcessingStatus = "Succeeded"
+	StatusFailed     ProcessingStatus = "Failed"
+)
+
+// all processing statuses
+var ProcessingStatuses = []ProcessingStatus{
+	StatusNotStarted, StatusUploading, StatusRunning, StatusSucceeded, StatusFailed,
+}
+
+func (s ProcessingStatus) String() string {
+	return string(s)
+}
+
+// check if the status is StatusSucceeded or StatusFailed
+func (s ProcessingStatus) IsDone() bool {
+	return s == StatusSucceeded || s == StatusFailed
+}
+
+// unmarshal case-insensitively (eg. "succeeded" of person group trainings => StatusSucceeded)
+//
+// unknown statuses are kept as they are
+func (s *ProcessingStatus) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	*s = processingStatusOf(str)
+	return nil
+}
+
+// typed status of given string, case-insensitively (unknown statuses are kept as they are)
+func processingStatusOf(str string) ProcessingStatus {
+	for _, status := range ProcessingStatuses {
+		if strings.EqualFold(str, string(status)) {
+			return status
+		}
+	}
+	return ProcessingStatus(str)
+}
@@ -0,0 +1,85 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestEnums(t *testing.T) {
+	for _, attribute := range FaceAttributes {
+		if !attribute.IsValid() {
+			t.Errorf("Face attribute should be valid: %s", attribute)
+		}
+	}
+	for _, feature := range VisualFeatures {
+		if !feature.IsValid() {
+			t.Errorf("Visual feature should be valid: %s", feature)
+		}
+	}
+	if FaceAttribute("Age").IsValid() || VisualFeature("tags").IsValid() || Detail("celebrity").IsValid() ||
+		FindSimilarMode("matchperson").IsValid() || OutputStyle("perframe").IsValid() || SensitivityLevel("highest").IsValid() {
+		t.Errorf("Typos should be invalid")
+	}
+
+	// statuses
+	var status struct {
+		Training FaceGetPersonGroupTrainingStatusResult
+		Unknown  ProcessingStatus
+	}
+	if err := json.Unmarshal([]byte(`{"Training":{"status":"succeeded"},"Unknown":"Paused"}`), &status); err != nil {
+		t.Fatalf("Failed to unmarshal statuses: %s", err)
+	}
+	if status.Training.Status != "succeeded" || status.Training.ProcessingStatus != StatusSucceeded || !status.Training.ProcessingStatus.IsDone() {
+		t.Errorf("Unexpected training status: %s, %s", status.Training.Status, status.Training.ProcessingStatus)
+	}
+	if s := processingStatusOf("Running"); s != StatusRunning || s.IsDone() {
+		t.Errorf("Unexpected operation status: %s", s)
+	}
+	if status.Unknown.String() != "Paused" {
+		t.Errorf("Unknown status should be kept: %s", status.Unknown)
+	}
+	if err := json.Unmarshal([]byte(`1`), &status.Unknown); err == nil {
+		t.Errorf("Unmarshaling a non-string status should fail")
+	}
+}
+
+func TestEnumsValidation(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+	ctx := context.Background()
+
+	if _, err := client.FaceDetect(ctx, WestUS, "key", "http://localhost/face.jpg", true, false, []string{"age", "gendre"}); err == nil {
+		t.Errorf("FaceDetect() should fail with an invalid attribute")
+	}
+	if _, err := client.FaceFindSimilar(ctx, WestUS, "key", "face-1", "list-1", nil, 10, "matchFaces"); err == nil {
+		t.Errorf("FaceFindSimilar() should fail with an invalid mode")
+	}
+	if _, err := client.ComputerVisionAnalyzeImage(ctx, WestUS, "key", "http://localhost/image.jpg", []string{"Tag"}, nil, ""); err == nil {
+		t.Errorf("ComputerVisionAnalyzeImage() should fail with an invalid visual feature")
+	}
+	if _, err := client.ComputerVisionAnalyzeImage(ctx, WestUS, "key", "http://localhost/image.jpg", nil, []string{"Celebrity"}, ""); err == nil {
+		t.Errorf("ComputerVisionAnalyzeImage() should fail with an invalid detail")
+	}
+	if _, err := client.EmotionRecognizeVideoSubmit(ctx, "key", "http://localhost/video.mp4", "perframe"); err == nil {
+		t.Errorf("EmotionRecognizeVideoSubmit() should fail with an invalid output style")
+	}
+	if _, err := client.VideoMotionDetectSubmit(ctx, "key", "http://localhost/video.mp4", "highest", 0, nil, false, 0); err == nil {
+		t.Errorf("VideoMotionDetectSubmit() should fail with an invalid sensitivity level")
+	}
+	if _, err := client.VideoMotionDetectSubmitWithOptions(ctx, WestUS, "key", "http://localhost/video.mp4", WithSensitivity("Medium")); err == nil {
+		t.Errorf("VideoMotionDetectSubmitWithOptions() should fail with an invalid sensitivity level")
+	}
+
+	if requests != 0 {
+		t.Errorf("Invalid parameters should not be sent: %d requests", requests)
+	}
+}
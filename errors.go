@@ -0,0 +1,140 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// error returned from the APIs
+//
+// can be checked with errors.As(), or helper functions like IsNotFound(), IsQuotaExceeded(), and IsRateLimited()
+type ApiError struct {
+	StatusCode int           // http status code
+	Code       string        // error code from the service (eg. "PersonGroupNotFound")
+	Message    string        // error message from the service
+	RequestId  string        // request id of the failed request (can be empty)
+	Body       []byte        // raw response body
+	RetryAfter time.Duration // value of Retry-After header (0 if not given)
+}
+
+func (e *ApiError) Error() string {
+	msg := fmt.Sprintf("HTTP %d", e.StatusCode)
+	if e.Code != "" {
+		msg += "; " + e.Code
+	}
+	if e.Message != "" {
+		msg += "; " + e.Message
+	}
+	if e.RequestId != "" {
+		msg += " (request id: " + e.RequestId + ")"
+	}
+	return msg
+}
+
+// create an ApiError from given http response and its body
+func newApiError(resp *http.Response, body []byte) *ApiError {
+	err := &ApiError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+	}
+
+	err.RequestId = requestIdOf(resp.Header)
+
+	// {"error": {"code": "...", "message": "..."}}
+	var apiResp ApiResponse
+	if json.Unmarshal(body, &apiResp) == nil && (apiResp.Error.Code != "" || apiResp.Error.Message != "") {
+		err.Code = apiResp.Error.Code
+		err.Message = apiResp.Error.Message
+
+		return err
+	}
+
+	// {"statusCode": 401, "message": "..."}
+	var errResp ApiResponseError
+	if json.Unmarshal(body, &errResp) == nil && errResp.Message != "" {
+		err.Message = errResp.Message
+
+		return err
+	}
+
+	// not in json format
+	if text := strings.TrimSpace(string(body)); text != "" {
+		err.Message = text
+	} else {
+		err.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return err
+}
+
+// get the request id from given response headers (empty if not given)
+func requestIdOf(header http.Header) string {
+	for _, h := range []string{"Apim-Request-Id", "X-Ms-Request-Id", "Request-Id"} {
+		if id := header.Get(h); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// parse value of Retry-After header (in seconds or http date)
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs > 0 {
+			return time.Duration(secs) * time.Second
+		}
+		return 0
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// check if given error is an ApiError which satisfies the condition
+func isApiError(err error, condition func(e *ApiError) bool) bool {
+	var apiErr *ApiError
+	if errors.As(err, &apiErr) {
+		return condition(apiErr)
+	}
+	return false
+}
+
+// check if given error means that the requested resource (eg. person group, face list) was not found
+func IsNotFound(err error) bool {
+	return isApiError(err, func(e *ApiError) bool {
+		return e.StatusCode == http.StatusNotFound || strings.HasSuffix(e.Code, "NotFound")
+	})
+}
+
+// check if given error means that the subscription's quota (or client-side monthly limit) was exceeded
+func IsQuotaExceeded(err error) bool {
+	if errors.Is(err, ErrMonthlyLimitExceeded) {
+		return true
+	}
+	return isApiError(err, func(e *ApiError) bool {
+		return e.StatusCode == http.StatusForbidden &&
+			(strings.Contains(strings.ToLower(e.Code), "quota") || strings.Contains(strings.ToLower(e.Message), "quota"))
+	})
+}
+
+// check if given error means that the request was rejected due to (server-side or client-side) rate limiting
+func IsRateLimited(err error) bool {
+	if errors.Is(err, ErrRateLimitExceeded) {
+		return true
+	}
+	return isApiError(err, func(e *ApiError) bool {
+		return e.StatusCode == http.StatusTooManyRequests || e.Code == "RateLimitExceeded"
+	})
+}
@@ -0,0 +1,123 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestApiError(t *testing.T) {
+	for _, test := range []struct {
+		status     int
+		header     http.Header
+		body       string
+		code       string
+		message    string
+		requestId  string
+		retryAfter time.Duration
+	}{
+		{
+			status:  404,
+			body:    `{"error": {"code": "PersonGroupNotFound", "message": "Person group is not found."}}`,
+			code:    "PersonGroupNotFound",
+			message: "Person group is not found.",
+		},
+		{
+			status:  401,
+			body:    `{"statusCode": 401, "message": "Access denied due to invalid subscription key."}`,
+			message: "Access denied due to invalid subscription key.",
+		},
+		{
+			status:     429,
+			header:     http.Header{"Retry-After": []string{"3"}, "Apim-Request-Id": []string{"req-1"}},
+			body:       `{"error": {"code": "RateLimitExceeded", "message": "Rate limit is exceeded."}}`,
+			code:       "RateLimitExceeded",
+			message:    "Rate limit is exceeded.",
+			requestId:  "req-1",
+			retryAfter: 3 * time.Second,
+		},
+		{
+			status:  502,
+			body:    `<html>Bad Gateway</html>`,
+			message: "<html>Bad Gateway</html>",
+		},
+		{
+			status:  503,
+			message: "Service Unavailable",
+		},
+	} {
+		resp := &http.Response{StatusCode: test.status, Header: test.header}
+		if resp.Header == nil {
+			resp.Header = http.Header{}
+		}
+
+		err := newApiError(resp, []byte(test.body))
+		if err.StatusCode != test.status ||
+			err.Code != test.code ||
+			err.Message != test.message ||
+			err.RequestId != test.requestId ||
+			err.RetryAfter != test.retryAfter ||
+			string(err.Body) != test.body {
+			t.Errorf("Unexpected error for HTTP %d: %+v", test.status, err)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for value, expected := range map[string]time.Duration{
+		"":                              0,
+		"10":                            10 * time.Second,
+		"-1":                            0,
+		"Sun, 01 Jan 2017 00:00:30 GMT": 30 * time.Second,
+		"Sat, 31 Dec 2016 23:59:00 GMT": 0,
+		"invalid":                       0,
+	} {
+		if d := parseRetryAfter(value, now); d != expected {
+			t.Errorf("parseRetryAfter(%q) = %s, expected: %s", value, d, expected)
+		}
+	}
+}
+
+func TestApiErrorHelpers(t *testing.T) {
+	notFound := fmt.Errorf("wrapped: %w", &ApiError{StatusCode: 404, Code: "PersonGroupNotFound"})
+	quota := &ApiError{StatusCode: 403, Code: "QuotaExceeded", Message: "Out of call volume quota."}
+	rateLimited := &ApiError{StatusCode: 429, Code: "RateLimitExceeded"}
+
+	if !IsNotFound(notFound) || IsNotFound(quota) || IsNotFound(errors.New("not found")) {
+		t.Errorf("IsNotFound() returned unexpected values")
+	}
+	if !IsQuotaExceeded(quota) || IsQuotaExceeded(rateLimited) {
+		t.Errorf("IsQuotaExceeded() returned unexpected values")
+	}
+	if !IsRateLimited(rateLimited) || IsRateLimited(notFound) {
+		t.Errorf("IsRateLimited() returned unexpected values")
+	}
+}
+
+func TestApiErrorFromServer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Apim-Request-Id", "req-2")
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, `{"error": {"code": "PersonGroupNotFound", "message": "Person group 'test' is not found."}}`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+
+	_, err := client.FaceGetPersonGroup(context.Background(), WestUS, "test-key", "test")
+
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("FaceGetPersonGroup() should return an *ApiError, got: %v", err)
+	}
+	if apiErr.Code != "PersonGroupNotFound" || apiErr.RequestId != "req-2" || !IsNotFound(err) {
+		t.Errorf("Unexpected error: %+v", apiErr)
+	}
+}
@@ -0,0 +1,149 @@
+package cognitive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+)
+
+// values of EXIF orientation tag
+const (
+	orientationNormal     = 1 // as it is
+	orientationFlipH      = 2 // flipped horizontally
+	orientationRotate180  = 3 // rotated 180 degrees
+	orientationFlipV      = 4 // flipped vertically
+	orientationTranspose  = 5 // flipped along the top-left to bottom-right diagonal
+	orientationRotate90   = 6 // should be rotated 90 degrees clockwise for display
+	orientationTransverse = 7 // flipped along the top-right to bottom-left diagonal
+	orientationRotate270  = 8 // should be rotated 90 degrees counterclockwise for display
+)
+
+// tag id of orientation in EXIF IFD0
+const exifTagOrientation = 0x0112
+
+// get the EXIF orientation of a jpeg image (orientationNormal if not given)
+func exifOrientation(data []byte) int {
+	if !bytes.HasPrefix(data, []byte{0xFF, 0xD8}) {
+		return orientationNormal
+	}
+
+	// iterate over the segments until the image data begins
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			break
+		}
+
+		marker := data[i+1]
+		if marker == 0xFF { // fill byte
+			i++
+			continue
+		}
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD8) { // segments without length
+			i += 2
+			continue
+		}
+		if marker == 0xDA || marker == 0xD9 { // start of scan, end of image
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2 : i+4]))
+		if length < 2 || i+2+length > len(data) {
+			break
+		}
+		if segment := data[i+4 : i+2+length]; marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) { // APP1
+			return tiffOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return orientationNormal
+}
+
+// get the orientation from IFD0 of a TIFF structure (orientationNormal if not given)
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return orientationNormal
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return orientationNormal
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return orientationNormal
+	}
+	entries := int(order.Uint16(tiff[ifd : ifd+2]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:entry+2]) == exifTagOrientation {
+			if orientation := int(order.Uint16(tiff[entry+8 : entry+10])); orientation >= orientationNormal && orientation <= orientationRotate270 {
+				return orientation
+			}
+			break
+		}
+	}
+	return orientationNormal
+}
+
+// check if width and height are swapped for displaying an image with given orientation
+func orientationSwapsSize(orientation int) bool {
+	return orientation >= orientationTranspose && orientation <= orientationRotate270
+}
+
+// rotate and flip given image, so that it is displayed correctly without its EXIF orientation
+func orientImage(src image.Image, orientation int) image.Image {
+	if orientation <= orientationNormal || orientation > orientationRotate270 {
+		return src
+	}
+
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok {
+		rgba = image.NewRGBA(bounds)
+		draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+	dstWidth, dstHeight := width, height
+	if orientationSwapsSize(orientation) {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var dx, dy int
+			switch orientation {
+			case orientationFlipH:
+				dx, dy = width-1-x, y
+			case orientationRotate180:
+				dx, dy = width-1-x, height-1-y
+			case orientationFlipV:
+				dx, dy = x, height-1-y
+			case orientationTranspose:
+				dx, dy = y, x
+			case orientationRotate90:
+				dx, dy = height-1-y, x
+			case orientationTransverse:
+				dx, dy = height-1-y, width-1-x
+			case orientationRotate270:
+				dx, dy = y, width-1-x
+			}
+
+			from := rgba.PixOffset(bounds.Min.X+x, bounds.Min.Y+y)
+			to := dst.PixOffset(dx, dy)
+			copy(dst.Pix[to:to+4], rgba.Pix[from:from+4])
+		}
+	}
+	return dst
+}
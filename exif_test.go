@@ -0,0 +1,120 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// encode a jpeg image of given dimensions, with EXIF orientation
+func testJpegWithOrientation(t *testing.T, width, height int, order binary.ByteOrder, orientation int) []byte {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	rand.New(rand.NewSource(int64(width * height))).Read(img.Pix)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatalf("Failed to encode jpeg: %s", err)
+	}
+	encoded := buf.Bytes()
+
+	// TIFF header + IFD0 with an orientation entry
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifTagOrientation)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], uint16(orientation))
+
+	app1 := append([]byte{0xFF, 0xE1, 0, 0}, append([]byte("Exif\x00\x00"), tiff...)...)
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(app1)-2))
+
+	return append(append([]byte{0xFF, 0xD8}, app1...), encoded[2:]...)
+}
+
+func TestExifOrientation(t *testing.T) {
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		for orientation := orientationNormal; orientation <= orientationRotate270; orientation++ {
+			if o := exifOrientation(testJpegWithOrientation(t, 8, 8, order, orientation)); o != orientation {
+				t.Errorf("exifOrientation() = %d, expected: %d (%s)", o, orientation, order)
+			}
+		}
+	}
+
+	// without EXIF
+	if o := exifOrientation(testPng(t, 8, 8)); o != orientationNormal {
+		t.Errorf("exifOrientation(png) = %d", o)
+	}
+}
+
+func TestOrientImage(t *testing.T) {
+	// 3x2 image with a marked top-left pixel
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	src.Set(0, 0, color.White)
+
+	for orientation, expected := range map[int]image.Point{
+		orientationNormal:     {0, 0},
+		orientationFlipH:      {2, 0},
+		orientationRotate180:  {2, 1},
+		orientationFlipV:      {0, 1},
+		orientationTranspose:  {0, 0},
+		orientationRotate90:   {1, 0},
+		orientationTransverse: {1, 2},
+		orientationRotate270:  {0, 2},
+	} {
+		dst := orientImage(src, orientation)
+
+		if size := dst.Bounds().Size(); orientationSwapsSize(orientation) != (size.X == 2) {
+			t.Errorf("Unexpected size for orientation %d: %s", orientation, size)
+		}
+		if r, _, _, _ := dst.At(expected.X, expected.Y).RGBA(); r != 0xFFFF {
+			t.Errorf("Marked pixel is not at %s for orientation %d", expected, orientation)
+		}
+	}
+}
+
+func TestPreprocessAutoOrient(t *testing.T) {
+	original := testJpegWithOrientation(t, 200, 100, binary.BigEndian, orientationRotate90)
+
+	var uploaded image.Config
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploaded, _, _ = image.DecodeConfig(bytes.NewReader(body))
+
+		if exifOrientation(body) != orientationNormal {
+			t.Errorf("EXIF orientation was not removed")
+		}
+
+		io.WriteString(w, `[{"faceId":"face-1","faceRectangle":{"left":10,"top":20,"width":30,"height":40}}]`)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		BaseUrl:    server.URL,
+		Preprocess: &PreprocessOptions{AutoOrient: true},
+	}
+	if result, err := client.FaceDetect(context.Background(), WestUS, "test-key", original, true, false, nil); err == nil {
+		if uploaded.Width != 100 || uploaded.Height != 200 {
+			t.Errorf("Image was not rotated: %dx%d", uploaded.Width, uploaded.Height)
+		}
+		if rect := result[0].FaceRectangle; rect != (Rectangle{Left: 10, Top: 20, Width: 30, Height: 40}) {
+			t.Errorf("Face rectangle should be in the displayed orientation: %+v", rect)
+		}
+	} else {
+		t.Errorf("FaceDetect() failed: %s", err)
+	}
+}
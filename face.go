@@ -1,6 +1,7 @@
 package cognitive
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -8,31 +9,23 @@ import (
 )
 
 type FaceDetectResult struct {
-	FaceId         string           `json:"faceId"`
-	FaceRectangle  Rectangle        `json:"faceRectangle"`
-	FaceLandmarks  map[string]Point `json:"faceLandmarks"`
-	FaceAttributes struct {
-		Age        float64            `json:"age"`
-		Gender     string             `json:"gender"`
-		Smile      float64            `json:"smile"`
-		FacialHair map[string]float64 `json:"facialHair"`
-		Glasses    string             `json:"glasses"`
-		HeadPose   map[string]float64 `json:"headPose"`
-		Emotion    map[string]float64 `json:"emotion"`
-	} `json:"faceAttributes"`
+	FaceId         string               `json:"faceId"`
+	FaceRectangle  Rectangle            `json:"faceRectangle"`
+	FaceLandmarks  *FaceLandmarks       `json:"faceLandmarks,omitempty"` // nil if not requested
+	FaceAttributes FaceDetectAttributes `json:"faceAttributes"`
 }
 
 type FaceFindSimilarRequest1 struct {
 	FaceId                    string `json:"faceId"`
 	FaceListId                string `json:"faceListId"`
-	MaxNumOfCandidateReturned string `json:"maxNumOfCandidatesReturned"`
+	MaxNumOfCandidateReturned int    `json:"maxNumOfCandidatesReturned"`
 	Mode                      string `json:"mode"`
 }
 
 type FaceFindSimilarRequest2 struct {
 	FaceId                    string   `json:"faceId"`
 	FaceIds                   []string `json:"faceIds"`
-	MaxNumOfCandidateReturned string   `json:"maxNumOfCandidatesReturned"`
+	MaxNumOfCandidateReturned int      `json:"maxNumOfCandidatesReturned"`
 	Mode                      string   `json:"mode"`
 }
 
@@ -171,7 +164,8 @@ type FaceGetPersonGroupResult struct {
 }
 
 type FaceGetPersonGroupTrainingStatusResult struct {
-	Status string `json:"status"`
+	Status           string           `json:"status"`
+	ProcessingStatus ProcessingStatus `json:"-"` // typed Status (StatusNotStarted, StatusRunning, StatusSucceeded, or StatusFailed)
 	/*
 		// XXX - in format of: '1/3/2017 4:11:35 AM'
 		CreatedDateTime    time.Time `json:"createdDateTime"`
@@ -182,6 +176,14 @@ type FaceGetPersonGroupTrainingStatusResult struct {
 	Message            string `json:"message"`
 }
 
+func (r *FaceGetPersonGroupTrainingStatusResult) UnmarshalJSON(data []byte) (err error) {
+	type result FaceGetPersonGroupTrainingStatusResult
+	if err = json.Unmarshal(data, (*result)(r)); err == nil {
+		r.ProcessingStatus = processingStatusOf(r.Status)
+	}
+	return err
+}
+
 type FaceGetPersonGroupsResult struct {
 	PersonGroupId string `json:"personGroupId"`
 	Name          string `json:"name"`
@@ -199,7 +201,7 @@ type FaceUpdatePersonGroupRequest struct {
 //
 // location             : API location
 // key                  : subscription key for this API
-// image                : string(image url) or []byte(image bytes array)
+// image                : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // returnFaceId         : (default: true)
 // returnFaceLandmarks  : (default: false)
 // returnFaceAttributes : "age", "gender", "headPose", "smile", "facialHair", "glasses", or "emotion"
@@ -211,7 +213,109 @@ func FaceDetect(
 	returnFaceLandmarks bool,
 	returnFaceAttributes []string,
 ) (processResult []FaceDetectResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/detect"
+	return FaceDetectWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		returnFaceId,
+		returnFaceLandmarks,
+		returnFaceAttributes,
+	)
+}
+
+// Face API: Detect (with context)
+//
+// ctx : cancels the request when done
+func FaceDetectWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	returnFaceId bool,
+	returnFaceLandmarks bool,
+	returnFaceAttributes []string,
+) (processResult []FaceDetectResult, err error) {
+	return DefaultClient.FaceDetect(
+		ctx,
+		location,
+		key,
+		image,
+		returnFaceId,
+		returnFaceLandmarks,
+		returnFaceAttributes,
+	)
+}
+
+// Face API: Detect (with client)
+//
+// same as FaceDetectWithContext(), but requested through this client
+func (c *Client) FaceDetect(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	returnFaceId bool,
+	returnFaceLandmarks bool,
+	returnFaceAttributes []string,
+) (processResult []FaceDetectResult, err error) {
+	return c.FaceDetectWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		func(o *options) {
+			o.returnFaceId = returnFaceId
+			o.returnFaceLandmarks = returnFaceLandmarks
+			o.returnFaceAttributes = enumsOf[FaceAttribute](returnFaceAttributes)
+		},
+	)
+}
+
+// Face API: Detect (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// image    : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// opts     : WithoutFaceId(), WithLandmarks(), WithAttributes()
+func FaceDetectWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult []FaceDetectResult, err error) {
+	return DefaultClient.FaceDetectWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		opts...,
+	)
+}
+
+// Face API: Detect (with options and client)
+//
+// same as FaceDetectWithOptions(), but requested through this client
+func (c *Client) FaceDetectWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	opts ...Option,
+) (processResult []FaceDetectResult, err error) {
+	var o *options
+	if o, err = newOptions(apiFaceDetect, opts); err != nil {
+		return []FaceDetectResult{}, err
+	}
+	returnFaceId, returnFaceLandmarks, returnFaceAttributes := o.returnFaceId, o.returnFaceLandmarks, o.returnFaceAttributes
+
+	if err = validateFaceAttributes(returnFaceAttributes); err != nil {
+		return []FaceDetectResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/detect")
 
 	// params
 	params := map[string]string{}
@@ -222,14 +326,23 @@ func FaceDetect(
 		params["returnFaceLandmarks"] = "true"
 	}
 	if len(returnFaceAttributes) > 0 {
-		params["returnFaceAttributes"] = strings.Join(returnFaceAttributes, ",")
+		attributes := []string{}
+		for _, attribute := range returnFaceAttributes {
+			attributes = append(attributes, attribute.String())
+		}
+		params["returnFaceAttributes"] = strings.Join(attributes, ",")
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	var transform *imageTransform
+	result, transform, err = c.postImage(ctx, apiUrl, key, params, image)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
+			for i := range processResult {
+				processResult[i].mapCoordinates(transform)
+			}
+
 			return processResult, nil
 		}
 	}
@@ -245,7 +358,7 @@ func FaceDetect(
 // faceId                     : (can get from FaceDetect())
 // faceListId                 : (can get from FaceListCreate())
 // faceIds                    : (can get from FaceDetect())
-// maxNumOfCandidatesReturned : 1 - 1000 (default: 20)
+// maxNumOfCandidatesReturned : 1 - 1000, or 0 for default (20)
 // mode                       : "matchPerson" or "matchFace" (default: "matchPerson")
 func FaceFindSimilar(
 	location ApiLocation,
@@ -256,40 +369,155 @@ func FaceFindSimilar(
 	maxNumOfCandidatesReturned int,
 	mode string,
 ) (processResult []FaceFindSimilarResult, err error) {
-	if faceListId != "" && len(faceIds) > 0 {
-		return []FaceFindSimilarResult{}, fmt.Errorf("faceListId and faceIds cannot be provided at the same time")
-	}
+	return FaceFindSimilarWithContext(
+		context.Background(),
+		location,
+		key,
+		faceId,
+		faceListId,
+		faceIds,
+		maxNumOfCandidatesReturned,
+		mode,
+	)
+}
 
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/findsimilars"
+// Face API: Find Similar (with context)
+//
+// ctx : cancels the request when done
+func FaceFindSimilarWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceId string,
+	faceListId string,
+	faceIds []string,
+	maxNumOfCandidatesReturned int,
+	mode string,
+) (processResult []FaceFindSimilarResult, err error) {
+	return DefaultClient.FaceFindSimilar(
+		ctx,
+		location,
+		key,
+		faceId,
+		faceListId,
+		faceIds,
+		maxNumOfCandidatesReturned,
+		mode,
+	)
+}
 
-	// json object
-	var obj interface{}
-	if maxNumOfCandidatesReturned < 1 || maxNumOfCandidatesReturned > 1000 {
+// Face API: Find Similar (with client)
+//
+// same as FaceFindSimilarWithContext(), but requested through this client
+func (c *Client) FaceFindSimilar(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceId string,
+	faceListId string,
+	faceIds []string,
+	maxNumOfCandidatesReturned int,
+	mode string,
+) (processResult []FaceFindSimilarResult, err error) {
+	return c.FaceFindSimilarWithOptions(
+		ctx,
+		location,
+		key,
+		faceId,
+		func(o *options) {
+			o.faceListId = faceListId
+			o.faceIds = faceIds
+			o.maxNumOfCandidatesReturned = maxNumOfCandidatesReturned
+			o.mode = FindSimilarMode(mode)
+		},
+	)
+}
+
+// Face API: Find Similar (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// faceId   : (can get from FaceDetect())
+// opts     : WithFaceList(), WithFaceIds(), WithMaxCandidates(), WithMode()
+func FaceFindSimilarWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceId string,
+	opts ...Option,
+) (processResult []FaceFindSimilarResult, err error) {
+	return DefaultClient.FaceFindSimilarWithOptions(
+		ctx,
+		location,
+		key,
+		faceId,
+		opts...,
+	)
+}
+
+// Face API: Find Similar (with options and client)
+//
+// same as FaceFindSimilarWithOptions(), but requested through this client
+func (c *Client) FaceFindSimilarWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceId string,
+	opts ...Option,
+) (processResult []FaceFindSimilarResult, err error) {
+	var o *options
+	if o, err = newOptions(apiFaceFindSimilar, opts); err != nil {
+		return []FaceFindSimilarResult{}, err
+	}
+	faceListId, faceIds, maxNumOfCandidatesReturned, mode := o.faceListId, o.faceIds, o.maxNumOfCandidatesReturned, o.mode
+
+	if maxNumOfCandidatesReturned == 0 {
 		maxNumOfCandidatesReturned = 20
 	}
 	if mode == "" {
-		mode = "matchPerson"
+		mode = FindSimilarMatchPerson
+	}
+
+	if faceListId != "" && len(faceIds) > 0 {
+		err = invalidParameter("faceListId", "faceListId and faceIds cannot be provided at the same time")
+	} else if faceListId != "" {
+		err = validateId("faceListId", faceListId)
+	} else if len(faceIds) > 0 {
+		err = validateCount("faceIds", len(faceIds), 1, 1000)
+	} else {
+		err = invalidParameter("faceListId", "both faceListId and faceIds are not provided")
 	}
+	if err = firstError(
+		err,
+		validateRange("maxNumOfCandidatesReturned", maxNumOfCandidatesReturned, 1, 1000),
+		validateFindSimilarMode(mode),
+	); err != nil {
+		return []FaceFindSimilarResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/findsimilars")
+
+	// json object
+	var obj interface{}
 	if faceListId != "" {
 		obj = FaceFindSimilarRequest1{
 			FaceId:                    faceId,
 			FaceListId:                faceListId,
-			MaxNumOfCandidateReturned: strconv.Itoa(maxNumOfCandidatesReturned),
-			Mode: mode,
+			MaxNumOfCandidateReturned: maxNumOfCandidatesReturned,
+			Mode:                      mode.String(),
 		}
-	} else if len(faceIds) > 0 {
+	} else {
 		obj = FaceFindSimilarRequest2{
 			FaceId:                    faceId,
 			FaceIds:                   faceIds,
-			MaxNumOfCandidateReturned: strconv.Itoa(maxNumOfCandidatesReturned),
-			Mode: mode,
+			MaxNumOfCandidateReturned: maxNumOfCandidatesReturned,
+			Mode:                      mode.String(),
 		}
-	} else {
-		return []FaceFindSimilarResult{}, fmt.Errorf("Both faceListId and faceIds are not provided")
 	}
 
 	var result []byte
-	result, err = httpPost(apiUrl, key, nil, obj)
+	result, err = c.httpPost(ctx, apiUrl, key, nil, obj)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -311,7 +539,45 @@ func FaceGroup(
 	key string,
 	faceIds []string,
 ) (processResult FaceGroupResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/group"
+	return FaceGroupWithContext(
+		context.Background(),
+		location,
+		key,
+		faceIds,
+	)
+}
+
+// Face API: Group (with context)
+//
+// ctx : cancels the request when done
+func FaceGroupWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceIds []string,
+) (processResult FaceGroupResult, err error) {
+	return DefaultClient.FaceGroup(
+		ctx,
+		location,
+		key,
+		faceIds,
+	)
+}
+
+// Face API: Group (with client)
+//
+// same as FaceGroupWithContext(), but requested through this client
+func (c *Client) FaceGroup(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceIds []string,
+) (processResult FaceGroupResult, err error) {
+	if err = validateCount("faceIds", len(faceIds), 1, 1000); err != nil {
+		return FaceGroupResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/group")
 
 	// json object
 	obj := FaceGroupRequest{
@@ -319,7 +585,7 @@ func FaceGroup(
 	}
 
 	var result []byte
-	result, err = httpPost(apiUrl, key, nil, obj)
+	result, err = c.httpPost(ctx, apiUrl, key, nil, obj)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -337,8 +603,8 @@ func FaceGroup(
 // key                        : subscription key for this API
 // faceIds                    : (can get from FaceDetect())
 // personGroupId              : (can get from FacePersonGroup())
-// maxNumOfCandidatesReturned : 1 - 5 (default: 1)
-// confidenceThreshold        : 0.0 - 1.0 (default: set automatically)
+// maxNumOfCandidatesReturned : 1 - 5, or 0 for default (1)
+// confidenceThreshold        : 0.0 - 1.0, or 0.0 for default (set automatically)
 func FaceIdentify(
 	location ApiLocation,
 	key string,
@@ -347,14 +613,126 @@ func FaceIdentify(
 	maxNumOfCandidatesReturned int,
 	confidenceThreshold float64,
 ) (processResult []FaceIdentifyResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/identify"
+	return FaceIdentifyWithContext(
+		context.Background(),
+		location,
+		key,
+		faceIds,
+		personGroupId,
+		maxNumOfCandidatesReturned,
+		confidenceThreshold,
+	)
+}
 
-	// json object
-	var obj interface{}
-	if maxNumOfCandidatesReturned < 1 || maxNumOfCandidatesReturned > 5 {
+// Face API: Identify (with context)
+//
+// ctx : cancels the request when done
+func FaceIdentifyWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceIds []string,
+	personGroupId string,
+	maxNumOfCandidatesReturned int,
+	confidenceThreshold float64,
+) (processResult []FaceIdentifyResult, err error) {
+	return DefaultClient.FaceIdentify(
+		ctx,
+		location,
+		key,
+		faceIds,
+		personGroupId,
+		maxNumOfCandidatesReturned,
+		confidenceThreshold,
+	)
+}
+
+// Face API: Identify (with client)
+//
+// same as FaceIdentifyWithContext(), but requested through this client
+func (c *Client) FaceIdentify(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceIds []string,
+	personGroupId string,
+	maxNumOfCandidatesReturned int,
+	confidenceThreshold float64,
+) (processResult []FaceIdentifyResult, err error) {
+	return c.FaceIdentifyWithOptions(
+		ctx,
+		location,
+		key,
+		faceIds,
+		personGroupId,
+		func(o *options) {
+			o.maxNumOfCandidatesReturned = maxNumOfCandidatesReturned
+			o.confidenceThreshold = confidenceThreshold
+		},
+	)
+}
+
+// Face API: Identify (with options)
+//
+// ctx           : cancels the request when done
+// location      : API location
+// key           : subscription key for this API
+// faceIds       : (can get from FaceDetect())
+// personGroupId : (can get from FacePersonGroup())
+// opts          : WithMaxCandidates(), WithConfidenceThreshold()
+func FaceIdentifyWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceIds []string,
+	personGroupId string,
+	opts ...Option,
+) (processResult []FaceIdentifyResult, err error) {
+	return DefaultClient.FaceIdentifyWithOptions(
+		ctx,
+		location,
+		key,
+		faceIds,
+		personGroupId,
+		opts...,
+	)
+}
+
+// Face API: Identify (with options and client)
+//
+// same as FaceIdentifyWithOptions(), but requested through this client
+func (c *Client) FaceIdentifyWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceIds []string,
+	personGroupId string,
+	opts ...Option,
+) (processResult []FaceIdentifyResult, err error) {
+	var o *options
+	if o, err = newOptions(apiFaceIdentify, opts); err != nil {
+		return []FaceIdentifyResult{}, err
+	}
+	maxNumOfCandidatesReturned, confidenceThreshold := o.maxNumOfCandidatesReturned, o.confidenceThreshold
+
+	if maxNumOfCandidatesReturned == 0 {
 		maxNumOfCandidatesReturned = 1
 	}
-	if confidenceThreshold >= 0.0 && confidenceThreshold <= 1.0 {
+
+	if err = firstError(
+		validateCount("faceIds", len(faceIds), 1, 10),
+		validateId("personGroupId", personGroupId),
+		validateRange("maxNumOfCandidatesReturned", maxNumOfCandidatesReturned, 1, 5),
+		validateRangeFloat("confidenceThreshold", confidenceThreshold, 0.0, 1.0),
+	); err != nil {
+		return []FaceIdentifyResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/identify")
+
+	// json object
+	var obj interface{}
+	if confidenceThreshold > 0.0 {
 		obj = FaceIdentifyRequest1{
 			FaceIds:                   faceIds,
 			PersonGroupId:             personGroupId,
@@ -370,7 +748,7 @@ func FaceIdentify(
 	}
 
 	var result []byte
-	result, err = httpPost(apiUrl, key, nil, obj)
+	result, err = c.httpPost(ctx, apiUrl, key, nil, obj)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -392,7 +770,41 @@ func FaceVerify(
 	key string,
 	obj interface{},
 ) (processResult FaceVerifyResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/verify"
+	return FaceVerifyWithContext(
+		context.Background(),
+		location,
+		key,
+		obj,
+	)
+}
+
+// Face API: Verify (with context)
+//
+// ctx : cancels the request when done
+func FaceVerifyWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	obj interface{},
+) (processResult FaceVerifyResult, err error) {
+	return DefaultClient.FaceVerify(
+		ctx,
+		location,
+		key,
+		obj,
+	)
+}
+
+// Face API: Verify (with client)
+//
+// same as FaceVerifyWithContext(), but requested through this client
+func (c *Client) FaceVerify(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	obj interface{},
+) (processResult FaceVerifyResult, err error) {
+	apiUrl := c.apiUrl(location, "/face/v1.0/verify")
 
 	// json object
 	switch obj.(type) {
@@ -402,7 +814,7 @@ func FaceVerify(
 	}
 
 	var result []byte
-	result, err = httpPost(apiUrl, key, nil, obj)
+	result, err = c.httpPost(ctx, apiUrl, key, nil, obj)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -418,7 +830,7 @@ func FaceVerify(
 //
 // location   : API location
 // key        : subscription key for this API
-// image      : string(image url) or []byte(image bytes array)
+// image      : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // faceListId : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
 // userData   : max 1kb
 // targetFace : if there're more than 1 faces, it should be passed
@@ -430,7 +842,123 @@ func FaceAddFaceToList(
 	userData string,
 	targetFace Rectangle,
 ) (processResult FaceAddToListResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists/" + faceListId + "/persistedFaces"
+	return FaceAddFaceToListWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		faceListId,
+		userData,
+		targetFace,
+	)
+}
+
+// Face API: Add a Face to a Face List (with context)
+//
+// ctx : cancels the request when done
+func FaceAddFaceToListWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	faceListId string,
+	userData string,
+	targetFace Rectangle,
+) (processResult FaceAddToListResult, err error) {
+	return DefaultClient.FaceAddFaceToList(
+		ctx,
+		location,
+		key,
+		image,
+		faceListId,
+		userData,
+		targetFace,
+	)
+}
+
+// Face API: Add a Face to a Face List (with client)
+//
+// same as FaceAddFaceToListWithContext(), but requested through this client
+func (c *Client) FaceAddFaceToList(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	faceListId string,
+	userData string,
+	targetFace Rectangle,
+) (processResult FaceAddToListResult, err error) {
+	return c.FaceAddFaceToListWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		faceListId,
+		func(o *options) {
+			o.userData = userData
+			o.targetFace = targetFace
+		},
+	)
+}
+
+// Face API: Add a Face to a Face List (with options)
+//
+// ctx        : cancels the request when done
+// location   : API location
+// key        : subscription key for this API
+// image      : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// faceListId : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
+// opts       : WithUserData(), WithTargetFace()
+func FaceAddFaceToListWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	faceListId string,
+	opts ...Option,
+) (processResult FaceAddToListResult, err error) {
+	return DefaultClient.FaceAddFaceToListWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		faceListId,
+		opts...,
+	)
+}
+
+// Face API: Add a Face to a Face List (with options and client)
+//
+// same as FaceAddFaceToListWithOptions(), but requested through this client
+func (c *Client) FaceAddFaceToListWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	faceListId string,
+	opts ...Option,
+) (processResult FaceAddToListResult, err error) {
+	var o *options
+	if o, err = newOptions(apiFaceAddFaceToList, opts); err != nil {
+		return FaceAddToListResult{}, err
+	}
+	userData, targetFace := o.userData, o.targetFace
+
+	if err = firstError(
+		validateId("faceListId", faceListId),
+		validateLength("userData", userData, MaxFaceUserDataLength),
+	); err != nil {
+		return FaceAddToListResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists/"+faceListId+"/persistedFaces")
+
+	// preprocess the image, and map the target face onto it
+	var transform *imageTransform
+	if image, transform, err = c.preprocessImage(apiUrl, image); err != nil {
+		return FaceAddToListResult{}, err
+	}
+	targetFace = transform.inverseRect(targetFace)
 
 	// params
 	params := map[string]string{}
@@ -442,7 +970,7 @@ func FaceAddFaceToList(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	result, err = c.postArg(ctx, apiUrl, key, params, image, MediaImage)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -468,7 +996,56 @@ func FaceCreateFaceList(
 	name string,
 	userData string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists/" + faceListId
+	return FaceCreateFaceListWithContext(
+		context.Background(),
+		location,
+		key,
+		faceListId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Create a Face List (with context)
+//
+// ctx : cancels the request when done
+func FaceCreateFaceListWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+	name string,
+	userData string,
+) (err error) {
+	return DefaultClient.FaceCreateFaceList(
+		ctx,
+		location,
+		key,
+		faceListId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Create a Face List (with client)
+//
+// same as FaceCreateFaceListWithContext(), but requested through this client
+func (c *Client) FaceCreateFaceList(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+	name string,
+	userData string,
+) (err error) {
+	if err = firstError(
+		validateId("faceListId", faceListId),
+		validateNameAndUserData(name, userData),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists/"+faceListId)
 
 	// json object
 	obj := FaceCreateFaceListRequest{
@@ -476,7 +1053,7 @@ func FaceCreateFaceList(
 		UserData: userData,
 	}
 
-	_, err = httpPut(apiUrl, key, nil, obj)
+	_, err = c.httpPut(ctx, apiUrl, key, nil, obj)
 
 	return err
 }
@@ -488,27 +1065,72 @@ func FaceCreateFaceList(
 // location        : API location
 // key             : subscription key for this API
 // faceListId      : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
-// persistedFaceId : (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64)
+// persistedFaceId : GUID (eg. 25985303-c537-4467-b41d-bdb45cd95ca1)
 func FaceDeleteFace(
 	location ApiLocation,
 	key string,
 	faceListId string,
 	persistedFaceId string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists/" + faceListId + "/persistedFaces/" + persistedFaceId
-
-	// params
-	params := map[string]string{
-		"faceListId":      faceListId,
-		"persistedFaceId": persistedFaceId,
-	}
-
-	_, err = httpDelete(apiUrl, key, params)
-
-	return err
+	return FaceDeleteFaceWithContext(
+		context.Background(),
+		location,
+		key,
+		faceListId,
+		persistedFaceId,
+	)
 }
 
-// Face API: Delete a Face List
+// Face API: Delete a Face from a Face List (with context)
+//
+// ctx : cancels the request when done
+func FaceDeleteFaceWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+	persistedFaceId string,
+) (err error) {
+	return DefaultClient.FaceDeleteFace(
+		ctx,
+		location,
+		key,
+		faceListId,
+		persistedFaceId,
+	)
+}
+
+// Face API: Delete a Face from a Face List (with client)
+//
+// same as FaceDeleteFaceWithContext(), but requested through this client
+func (c *Client) FaceDeleteFace(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+	persistedFaceId string,
+) (err error) {
+	if err = firstError(
+		validateId("faceListId", faceListId),
+		validateGuid("persistedFaceId", persistedFaceId),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists/"+faceListId+"/persistedFaces/"+persistedFaceId)
+
+	// params
+	params := map[string]string{
+		"faceListId":      faceListId,
+		"persistedFaceId": persistedFaceId,
+	}
+
+	_, err = c.httpDelete(ctx, apiUrl, key, params)
+
+	return err
+}
+
+// Face API: Delete a Face List
 //
 // https://westus.dev.cognitive.microsoft.com/docs/services/563879b61984550e40cbbe8d/operations/563879b61984550f3039524f
 //
@@ -520,14 +1142,52 @@ func FaceDeleteFaceList(
 	key string,
 	faceListId string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists/" + faceListId
+	return FaceDeleteFaceListWithContext(
+		context.Background(),
+		location,
+		key,
+		faceListId,
+	)
+}
+
+// Face API: Delete a Face List (with context)
+//
+// ctx : cancels the request when done
+func FaceDeleteFaceListWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+) (err error) {
+	return DefaultClient.FaceDeleteFaceList(
+		ctx,
+		location,
+		key,
+		faceListId,
+	)
+}
+
+// Face API: Delete a Face List (with client)
+//
+// same as FaceDeleteFaceListWithContext(), but requested through this client
+func (c *Client) FaceDeleteFaceList(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+) (err error) {
+	if err = validateId("faceListId", faceListId); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists/"+faceListId)
 
 	// params
 	params := map[string]string{
 		"faceListId": faceListId,
 	}
 
-	_, err = httpDelete(apiUrl, key, params)
+	_, err = c.httpDelete(ctx, apiUrl, key, params)
 
 	return err
 }
@@ -544,10 +1204,48 @@ func FaceGetFaces(
 	key string,
 	faceListId string,
 ) (processResult FaceFacesResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists/" + faceListId
+	return FaceGetFacesWithContext(
+		context.Background(),
+		location,
+		key,
+		faceListId,
+	)
+}
+
+// Face API: Get a Face List (with context)
+//
+// ctx : cancels the request when done
+func FaceGetFacesWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+) (processResult FaceFacesResult, err error) {
+	return DefaultClient.FaceGetFaces(
+		ctx,
+		location,
+		key,
+		faceListId,
+	)
+}
+
+// Face API: Get a Face List (with client)
+//
+// same as FaceGetFacesWithContext(), but requested through this client
+func (c *Client) FaceGetFaces(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+) (processResult FaceFacesResult, err error) {
+	if err = validateId("faceListId", faceListId); err != nil {
+		return FaceFacesResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists/"+faceListId)
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -567,10 +1265,40 @@ func FaceGetLists(
 	location ApiLocation,
 	key string,
 ) (processResult []FaceListResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists"
+	return FaceGetListsWithContext(
+		context.Background(),
+		location,
+		key,
+	)
+}
+
+// Face API: List Face Lists (with context)
+//
+// ctx : cancels the request when done
+func FaceGetListsWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+) (processResult []FaceListResult, err error) {
+	return DefaultClient.FaceGetLists(
+		ctx,
+		location,
+		key,
+	)
+}
+
+// Face API: List Face Lists (with client)
+//
+// same as FaceGetListsWithContext(), but requested through this client
+func (c *Client) FaceGetLists(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+) (processResult []FaceListResult, err error) {
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists")
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -596,7 +1324,56 @@ func FaceUpdateFaceList(
 	name string,
 	userData string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/facelists/" + faceListId
+	return FaceUpdateFaceListWithContext(
+		context.Background(),
+		location,
+		key,
+		faceListId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Update a Face List (with context)
+//
+// ctx : cancels the request when done
+func FaceUpdateFaceListWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+	name string,
+	userData string,
+) (err error) {
+	return DefaultClient.FaceUpdateFaceList(
+		ctx,
+		location,
+		key,
+		faceListId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Update a Face List (with client)
+//
+// same as FaceUpdateFaceListWithContext(), but requested through this client
+func (c *Client) FaceUpdateFaceList(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	faceListId string,
+	name string,
+	userData string,
+) (err error) {
+	if err = firstError(
+		validateId("faceListId", faceListId),
+		validateNameAndUserData(name, userData),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/facelists/"+faceListId)
 
 	// object
 	obj := FaceUpdateFaceListRequest{
@@ -604,7 +1381,7 @@ func FaceUpdateFaceList(
 		UserData: userData,
 	}
 
-	_, err = httpPatch(apiUrl, key, nil, obj)
+	_, err = c.httpPatch(ctx, apiUrl, key, nil, obj)
 
 	return err
 }
@@ -615,7 +1392,7 @@ func FaceUpdateFaceList(
 //
 // location      : API location
 // key           : subscription key for this API
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // personGroupId :
 // personId      :
 // userData      : max 1kb
@@ -629,7 +1406,133 @@ func FaceAddPersonFace(
 	userData string,
 	targetFace Rectangle,
 ) (processResult FaceAddPersonFaceResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId + "/persistedFaces"
+	return FaceAddPersonFaceWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		personGroupId,
+		personId,
+		userData,
+		targetFace,
+	)
+}
+
+// Face API: Add a Person Face (with context)
+//
+// ctx : cancels the request when done
+func FaceAddPersonFaceWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	personGroupId string,
+	personId string,
+	userData string,
+	targetFace Rectangle,
+) (processResult FaceAddPersonFaceResult, err error) {
+	return DefaultClient.FaceAddPersonFace(
+		ctx,
+		location,
+		key,
+		image,
+		personGroupId,
+		personId,
+		userData,
+		targetFace,
+	)
+}
+
+// Face API: Add a Person Face (with client)
+//
+// same as FaceAddPersonFaceWithContext(), but requested through this client
+func (c *Client) FaceAddPersonFace(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	personGroupId string,
+	personId string,
+	userData string,
+	targetFace Rectangle,
+) (processResult FaceAddPersonFaceResult, err error) {
+	return c.FaceAddPersonFaceWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		personGroupId,
+		personId,
+		func(o *options) {
+			o.userData = userData
+			o.targetFace = targetFace
+		},
+	)
+}
+
+// Face API: Add a Person Face (with options)
+//
+// ctx           : cancels the request when done
+// location      : API location
+// key           : subscription key for this API
+// image         : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
+// personGroupId :
+// personId      :
+// opts          : WithUserData(), WithTargetFace()
+func FaceAddPersonFaceWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	personGroupId string,
+	personId string,
+	opts ...Option,
+) (processResult FaceAddPersonFaceResult, err error) {
+	return DefaultClient.FaceAddPersonFaceWithOptions(
+		ctx,
+		location,
+		key,
+		image,
+		personGroupId,
+		personId,
+		opts...,
+	)
+}
+
+// Face API: Add a Person Face (with options and client)
+//
+// same as FaceAddPersonFaceWithOptions(), but requested through this client
+func (c *Client) FaceAddPersonFaceWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	personGroupId string,
+	personId string,
+	opts ...Option,
+) (processResult FaceAddPersonFaceResult, err error) {
+	var o *options
+	if o, err = newOptions(apiFaceAddPersonFace, opts); err != nil {
+		return FaceAddPersonFaceResult{}, err
+	}
+	userData, targetFace := o.userData, o.targetFace
+
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+		validateLength("userData", userData, MaxFaceUserDataLength),
+	); err != nil {
+		return FaceAddPersonFaceResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId+"/persistedFaces")
+
+	// preprocess the image, and map the target face onto it
+	var transform *imageTransform
+	if image, transform, err = c.preprocessImage(apiUrl, image); err != nil {
+		return FaceAddPersonFaceResult{}, err
+	}
+	targetFace = transform.inverseRect(targetFace)
 
 	// params
 	params := map[string]string{}
@@ -641,7 +1544,7 @@ func FaceAddPersonFace(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, image)
+	result, err = c.postArg(ctx, apiUrl, key, params, image, MediaImage)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -657,7 +1560,7 @@ func FaceAddPersonFace(
 //
 // location      : API location
 // key           : subscription key for this API
-// image         : string(image url) or []byte(image bytes array)
+// image         : string(image url), []byte(image bytes array), File(image file path), or io.Reader(image stream)
 // personGroupId :
 // name          : max length = 128
 // userData      : max 16kb
@@ -669,7 +1572,60 @@ func FaceCreatePerson(
 	name string,
 	userData string,
 ) (processResult FaceCreatePersonResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons"
+	return FaceCreatePersonWithContext(
+		context.Background(),
+		location,
+		key,
+		image,
+		personGroupId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Create a Person (with context)
+//
+// ctx : cancels the request when done
+func FaceCreatePersonWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	personGroupId string,
+	name string,
+	userData string,
+) (processResult FaceCreatePersonResult, err error) {
+	return DefaultClient.FaceCreatePerson(
+		ctx,
+		location,
+		key,
+		image,
+		personGroupId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Create a Person (with client)
+//
+// same as FaceCreatePersonWithContext(), but requested through this client
+func (c *Client) FaceCreatePerson(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	image interface{},
+	personGroupId string,
+	name string,
+	userData string,
+) (processResult FaceCreatePersonResult, err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateNameAndUserData(name, userData),
+	); err != nil {
+		return FaceCreatePersonResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons")
 
 	// params
 	obj := FaceCreatePersonRequest{
@@ -678,7 +1634,7 @@ func FaceCreatePerson(
 	}
 
 	var result []byte
-	result, err = httpPost(apiUrl, key, nil, obj)
+	result, err = c.httpPost(ctx, apiUrl, key, nil, obj)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -702,9 +1658,54 @@ func FaceDeletePerson(
 	personGroupId string,
 	personId string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId
+	return FaceDeletePersonWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		personId,
+	)
+}
+
+// Face API: Delete a Person (with context)
+//
+// ctx : cancels the request when done
+func FaceDeletePersonWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+) (err error) {
+	return DefaultClient.FaceDeletePerson(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		personId,
+	)
+}
+
+// Face API: Delete a Person (with client)
+//
+// same as FaceDeletePersonWithContext(), but requested through this client
+func (c *Client) FaceDeletePerson(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+) (err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId)
 
-	_, err = httpDelete(apiUrl, key, nil)
+	_, err = c.httpDelete(ctx, apiUrl, key, nil)
 
 	return err
 }
@@ -725,9 +1726,59 @@ func FaceDeletePersonFace(
 	personId string,
 	persistedFaceId string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId + "/persistedFaces/" + persistedFaceId
+	return FaceDeletePersonFaceWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		personId,
+		persistedFaceId,
+	)
+}
+
+// Face API: Delete a Person Face (with context)
+//
+// ctx : cancels the request when done
+func FaceDeletePersonFaceWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+) (err error) {
+	return DefaultClient.FaceDeletePersonFace(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		personId,
+		persistedFaceId,
+	)
+}
+
+// Face API: Delete a Person Face (with client)
+//
+// same as FaceDeletePersonFaceWithContext(), but requested through this client
+func (c *Client) FaceDeletePersonFace(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+) (err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+		validateGuid("persistedFaceId", persistedFaceId),
+	); err != nil {
+		return err
+	}
 
-	_, err = httpDelete(apiUrl, key, nil)
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId+"/persistedFaces/"+persistedFaceId)
+
+	_, err = c.httpDelete(ctx, apiUrl, key, nil)
 
 	return err
 }
@@ -746,10 +1797,55 @@ func FaceGetPerson(
 	personGroupId string,
 	personId string,
 ) (processResult FaceGetPersonResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId
+	return FaceGetPersonWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		personId,
+	)
+}
+
+// Face API: Get a Person (with context)
+//
+// ctx : cancels the request when done
+func FaceGetPersonWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+) (processResult FaceGetPersonResult, err error) {
+	return DefaultClient.FaceGetPerson(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		personId,
+	)
+}
+
+// Face API: Get a Person (with client)
+//
+// same as FaceGetPersonWithContext(), but requested through this client
+func (c *Client) FaceGetPerson(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+) (processResult FaceGetPersonResult, err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+	); err != nil {
+		return FaceGetPersonResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId)
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -775,10 +1871,60 @@ func FaceGetPersonFace(
 	personId string,
 	persistedFaceId string,
 ) (processResult FaceGetPersonFaceResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId + "/persistedFaces/" + persistedFaceId
+	return FaceGetPersonFaceWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		personId,
+		persistedFaceId,
+	)
+}
+
+// Face API: Get a Person Face (with context)
+//
+// ctx : cancels the request when done
+func FaceGetPersonFaceWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+) (processResult FaceGetPersonFaceResult, err error) {
+	return DefaultClient.FaceGetPersonFace(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		personId,
+		persistedFaceId,
+	)
+}
+
+// Face API: Get a Person Face (with client)
+//
+// same as FaceGetPersonFaceWithContext(), but requested through this client
+func (c *Client) FaceGetPersonFace(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+) (processResult FaceGetPersonFaceResult, err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+		validateGuid("persistedFaceId", persistedFaceId),
+	); err != nil {
+		return FaceGetPersonFaceResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId+"/persistedFaces/"+persistedFaceId)
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -800,10 +1946,48 @@ func FaceGetPersons(
 	key string,
 	personGroupId string,
 ) (processResult []FaceGetPersonsResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons"
+	return FaceGetPersonsWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: List Persons in a Person Group (with context)
+//
+// ctx : cancels the request when done
+func FaceGetPersonsWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (processResult []FaceGetPersonsResult, err error) {
+	return DefaultClient.FaceGetPersons(
+		ctx,
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: List Persons in a Person Group (with client)
+//
+// same as FaceGetPersonsWithContext(), but requested through this client
+func (c *Client) FaceGetPersons(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (processResult []FaceGetPersonsResult, err error) {
+	if err = validateId("personGroupId", personGroupId); err != nil {
+		return []FaceGetPersonsResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons")
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -831,7 +2015,61 @@ func FaceUpdatePerson(
 	name string,
 	userData string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId
+	return FaceUpdatePersonWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		personId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Update a Person (with context)
+//
+// ctx : cancels the request when done
+func FaceUpdatePersonWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	name string,
+	userData string,
+) (err error) {
+	return DefaultClient.FaceUpdatePerson(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		personId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Update a Person (with client)
+//
+// same as FaceUpdatePersonWithContext(), but requested through this client
+func (c *Client) FaceUpdatePerson(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	name string,
+	userData string,
+) (err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+		validateNameAndUserData(name, userData),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId)
 
 	// object
 	obj := FaceUpdatePersonRequest{
@@ -839,7 +2077,7 @@ func FaceUpdatePerson(
 		UserData: userData,
 	}
 
-	_, err = httpPatch(apiUrl, key, nil, obj)
+	_, err = c.httpPatch(ctx, apiUrl, key, nil, obj)
 
 	return err
 }
@@ -862,14 +2100,69 @@ func FaceUpdatePersonFace(
 	persistedFaceId string,
 	userData string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/persons/" + personId + "/persistedFaces/" + persistedFaceId
+	return FaceUpdatePersonFaceWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		personId,
+		persistedFaceId,
+		userData,
+	)
+}
+
+// Face API: Update a Person Face (with context)
+//
+// ctx : cancels the request when done
+func FaceUpdatePersonFaceWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+	userData string,
+) (err error) {
+	return DefaultClient.FaceUpdatePersonFace(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		personId,
+		persistedFaceId,
+		userData,
+	)
+}
+
+// Face API: Update a Person Face (with client)
+//
+// same as FaceUpdatePersonFaceWithContext(), but requested through this client
+func (c *Client) FaceUpdatePersonFace(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	personId string,
+	persistedFaceId string,
+	userData string,
+) (err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateGuid("personId", personId),
+		validateGuid("persistedFaceId", persistedFaceId),
+		validateLength("userData", userData, MaxFaceUserDataLength),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/persons/"+personId+"/persistedFaces/"+persistedFaceId)
 
 	// object
 	obj := FaceUpdatePersonFaceRequest{
 		UserData: userData,
 	}
 
-	_, err = httpPatch(apiUrl, key, nil, obj)
+	_, err = c.httpPatch(ctx, apiUrl, key, nil, obj)
 
 	return err
 }
@@ -890,7 +2183,56 @@ func FaceCreatePersonGroup(
 	name string,
 	userData string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId
+	return FaceCreatePersonGroupWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Create a Person Group (with context)
+//
+// ctx : cancels the request when done
+func FaceCreatePersonGroupWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	name string,
+	userData string,
+) (err error) {
+	return DefaultClient.FaceCreatePersonGroup(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Create a Person Group (with client)
+//
+// same as FaceCreatePersonGroupWithContext(), but requested through this client
+func (c *Client) FaceCreatePersonGroup(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	name string,
+	userData string,
+) (err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateNameAndUserData(name, userData),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId)
 
 	// json object
 	obj := FaceCreatePersonGroupRequest{
@@ -898,7 +2240,7 @@ func FaceCreatePersonGroup(
 		UserData: userData,
 	}
 
-	_, err = httpPut(apiUrl, key, nil, obj)
+	_, err = c.httpPut(ctx, apiUrl, key, nil, obj)
 
 	return err
 }
@@ -915,9 +2257,47 @@ func FaceDeletePersonGroup(
 	key string,
 	personGroupId string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId
+	return FaceDeletePersonGroupWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+	)
+}
 
-	_, err = httpDelete(apiUrl, key, nil)
+// Face API: Delete a Person Group (with context)
+//
+// ctx : cancels the request when done
+func FaceDeletePersonGroupWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (err error) {
+	return DefaultClient.FaceDeletePersonGroup(
+		ctx,
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Delete a Person Group (with client)
+//
+// same as FaceDeletePersonGroupWithContext(), but requested through this client
+func (c *Client) FaceDeletePersonGroup(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (err error) {
+	if err = validateId("personGroupId", personGroupId); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId)
+
+	_, err = c.httpDelete(ctx, apiUrl, key, nil)
 
 	return err
 }
@@ -934,10 +2314,48 @@ func FaceGetPersonGroup(
 	key string,
 	personGroupId string,
 ) (processResult FaceGetPersonGroupResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId
+	return FaceGetPersonGroupWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Get a Person Group (with context)
+//
+// ctx : cancels the request when done
+func FaceGetPersonGroupWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (processResult FaceGetPersonGroupResult, err error) {
+	return DefaultClient.FaceGetPersonGroup(
+		ctx,
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Get a Person Group (with client)
+//
+// same as FaceGetPersonGroupWithContext(), but requested through this client
+func (c *Client) FaceGetPersonGroup(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (processResult FaceGetPersonGroupResult, err error) {
+	if err = validateId("personGroupId", personGroupId); err != nil {
+		return FaceGetPersonGroupResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId)
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -959,10 +2377,48 @@ func FaceGetPersonGroupTrainingStatus(
 	key string,
 	personGroupId string,
 ) (processResult FaceGetPersonGroupTrainingStatusResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/training"
+	return FaceGetPersonGroupTrainingStatusWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Get Person Group Training Status (with context)
+//
+// ctx : cancels the request when done
+func FaceGetPersonGroupTrainingStatusWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (processResult FaceGetPersonGroupTrainingStatusResult, err error) {
+	return DefaultClient.FaceGetPersonGroupTrainingStatus(
+		ctx,
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Get Person Group Training Status (with client)
+//
+// same as FaceGetPersonGroupTrainingStatusWithContext(), but requested through this client
+func (c *Client) FaceGetPersonGroupTrainingStatus(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (processResult FaceGetPersonGroupTrainingStatusResult, err error) {
+	if err = validateId("personGroupId", personGroupId); err != nil {
+		return FaceGetPersonGroupTrainingStatusResult{}, err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/training")
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, nil)
+	result, err = c.httpGet(ctx, apiUrl, key, nil)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -978,27 +2434,71 @@ func FaceGetPersonGroupTrainingStatus(
 //
 // location : API location
 // key      : subscription key for this API
-// start    : 0 - 64 characters
-// top      : 1 - 1000 (default 1000)
+// start    : id of the person group to list after (valid chars: letter in lower case or digit or '-' or '_', maximum length is 64), or empty
+// top      : 1 - 1000, or 0 for default (1000)
 func FaceGetPersonGroups(
 	location ApiLocation,
 	key string,
 	start string,
 	top int,
 ) (processResult []FaceGetPersonGroupsResult, err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups"
+	return FaceGetPersonGroupsWithContext(
+		context.Background(),
+		location,
+		key,
+		start,
+		top,
+	)
+}
+
+// Face API: List Person Groups (with context)
+//
+// ctx : cancels the request when done
+func FaceGetPersonGroupsWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	start string,
+	top int,
+) (processResult []FaceGetPersonGroupsResult, err error) {
+	return DefaultClient.FaceGetPersonGroups(
+		ctx,
+		location,
+		key,
+		start,
+		top,
+	)
+}
+
+// Face API: List Person Groups (with client)
+//
+// same as FaceGetPersonGroupsWithContext(), but requested through this client
+func (c *Client) FaceGetPersonGroups(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	start string,
+	top int,
+) (processResult []FaceGetPersonGroupsResult, err error) {
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups")
 
 	params := map[string]string{}
 	if start != "" {
+		if err = validateId("start", start); err != nil {
+			return []FaceGetPersonGroupsResult{}, err
+		}
 		params["start"] = start
 	}
-	if top < 1 || top > 1000 {
+	if top == 0 {
 		top = 1000
 	}
+	if err = validateRange("top", top, 1, 1000); err != nil {
+		return []FaceGetPersonGroupsResult{}, err
+	}
 	params["top"] = strconv.Itoa(top)
 
 	var result []byte
-	result, err = httpGet(apiUrl, key, params)
+	result, err = c.httpGet(ctx, apiUrl, key, params)
 
 	if err == nil {
 		if err = json.Unmarshal(result, &processResult); err == nil {
@@ -1020,9 +2520,47 @@ func FaceTrainPersonGroup(
 	key string,
 	personGroupId string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId + "/train"
+	return FaceTrainPersonGroupWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Train Person Group (with context)
+//
+// ctx : cancels the request when done
+func FaceTrainPersonGroupWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (err error) {
+	return DefaultClient.FaceTrainPersonGroup(
+		ctx,
+		location,
+		key,
+		personGroupId,
+	)
+}
+
+// Face API: Train Person Group (with client)
+//
+// same as FaceTrainPersonGroupWithContext(), but requested through this client
+func (c *Client) FaceTrainPersonGroup(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+) (err error) {
+	if err = validateId("personGroupId", personGroupId); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId+"/train")
 
-	_, err = httpPost(apiUrl, key, nil, nil)
+	_, err = c.httpPost(ctx, apiUrl, key, nil, nil)
 
 	return err
 }
@@ -1041,7 +2579,56 @@ func FaceUpdatePersonGroup(
 	name string,
 	userData string,
 ) (err error) {
-	apiUrl := "https://" + string(location) + ".api.cognitive.microsoft.com/face/v1.0/persongroups/" + personGroupId
+	return FaceUpdatePersonGroupWithContext(
+		context.Background(),
+		location,
+		key,
+		personGroupId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Update a Person Group (with context)
+//
+// ctx : cancels the request when done
+func FaceUpdatePersonGroupWithContext(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	name string,
+	userData string,
+) (err error) {
+	return DefaultClient.FaceUpdatePersonGroup(
+		ctx,
+		location,
+		key,
+		personGroupId,
+		name,
+		userData,
+	)
+}
+
+// Face API: Update a Person Group (with client)
+//
+// same as FaceUpdatePersonGroupWithContext(), but requested through this client
+func (c *Client) FaceUpdatePersonGroup(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	personGroupId string,
+	name string,
+	userData string,
+) (err error) {
+	if err = firstError(
+		validateId("personGroupId", personGroupId),
+		validateNameAndUserData(name, userData),
+	); err != nil {
+		return err
+	}
+
+	apiUrl := c.apiUrl(location, "/face/v1.0/persongroups/"+personGroupId)
 
 	// object
 	obj := FaceUpdatePersonGroupRequest{
@@ -1049,7 +2636,7 @@ func FaceUpdatePersonGroup(
 		UserData: userData,
 	}
 
-	_, err = httpPatch(apiUrl, key, nil, obj)
+	_, err = c.httpPatch(ctx, apiUrl, key, nil, obj)
 
 	return err
 }
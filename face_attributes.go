@@ -0,0 +1,246 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// gender of a detected face
+type Gender string
+
+const (
+	GenderMale   Gender = "male"
+	GenderFemale Gender = "female"
+)
+
+func (g Gender) String() string {
+	return string(g)
+}
+
+// glasses type of a detected face
+type Glasses string
+
+const (
+	NoGlasses       Glasses = "NoGlasses"
+	ReadingGlasses  Glasses = "ReadingGlasses"
+	Sunglasses      Glasses = "Sunglasses"
+	SwimmingGoggles Glasses = "SwimmingGoggles"
+)
+
+func (g Glasses) String() string {
+	return string(g)
+}
+
+// face attributes of a detected face (only the ones requested with returnFaceAttributes are filled)
+//
+// fields which are not known to this library are kept in Extra
+type FaceDetectAttributes struct {
+	Age        float64       `json:"age"`
+	Gender     Gender        `json:"gender"`
+	Smile      float64       `json:"smile"`
+	FacialHair FacialHair    `json:"facialHair"`
+	Glasses    Glasses       `json:"glasses"`
+	HeadPose   HeadPose      `json:"headPose"`
+	Emotion    EmotionScores `json:"emotion"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (a *FaceDetectAttributes) UnmarshalJSON(data []byte) (err error) {
+	type attributes FaceDetectAttributes
+	a.Extra, err = unmarshalWithExtra(data, (*attributes)(a))
+	return err
+}
+
+func (a FaceDetectAttributes) MarshalJSON() ([]byte, error) {
+	type attributes FaceDetectAttributes
+	return marshalWithExtra(attributes(a), a.Extra)
+}
+
+// head pose of a detected face (in degrees)
+type HeadPose struct {
+	Roll  float64 `json:"roll"`
+	Yaw   float64 `json:"yaw"`
+	Pitch float64 `json:"pitch"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (h *HeadPose) UnmarshalJSON(data []byte) (err error) {
+	type headPose HeadPose
+	h.Extra, err = unmarshalWithExtra(data, (*headPose)(h))
+	return err
+}
+
+func (h HeadPose) MarshalJSON() ([]byte, error) {
+	type headPose HeadPose
+	return marshalWithExtra(headPose(h), h.Extra)
+}
+
+// facial hair of a detected face (0.0 - 1.0)
+type FacialHair struct {
+	Moustache float64 `json:"moustache"`
+	Beard     float64 `json:"beard"`
+	Sideburns float64 `json:"sideburns"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (f *FacialHair) UnmarshalJSON(data []byte) (err error) {
+	type facialHair FacialHair
+	f.Extra, err = unmarshalWithExtra(data, (*facialHair)(f))
+	return err
+}
+
+func (f FacialHair) MarshalJSON() ([]byte, error) {
+	type facialHair FacialHair
+	return marshalWithExtra(facialHair(f), f.Extra)
+}
+
+// 27-point face landmarks of a detected face
+//
+// landmarks which are not known to this library are kept in Extra
+type FaceLandmarks struct {
+	PupilLeft           Point `json:"pupilLeft"`
+	PupilRight          Point `json:"pupilRight"`
+	NoseTip             Point `json:"noseTip"`
+	MouthLeft           Point `json:"mouthLeft"`
+	MouthRight          Point `json:"mouthRight"`
+	EyebrowLeftOuter    Point `json:"eyebrowLeftOuter"`
+	EyebrowLeftInner    Point `json:"eyebrowLeftInner"`
+	EyeLeftOuter        Point `json:"eyeLeftOuter"`
+	EyeLeftTop          Point `json:"eyeLeftTop"`
+	EyeLeftBottom       Point `json:"eyeLeftBottom"`
+	EyeLeftInner        Point `json:"eyeLeftInner"`
+	EyebrowRightInner   Point `json:"eyebrowRightInner"`
+	EyebrowRightOuter   Point `json:"eyebrowRightOuter"`
+	EyeRightInner       Point `json:"eyeRightInner"`
+	EyeRightTop         Point `json:"eyeRightTop"`
+	EyeRightBottom      Point `json:"eyeRightBottom"`
+	EyeRightOuter       Point `json:"eyeRightOuter"`
+	NoseRootLeft        Point `json:"noseRootLeft"`
+	NoseRootRight       Point `json:"noseRootRight"`
+	NoseLeftAlarTop     Point `json:"noseLeftAlarTop"`
+	NoseRightAlarTop    Point `json:"noseRightAlarTop"`
+	NoseLeftAlarOutTip  Point `json:"noseLeftAlarOutTip"`
+	NoseRightAlarOutTip Point `json:"noseRightAlarOutTip"`
+	UpperLipTop         Point `json:"upperLipTop"`
+	UpperLipBottom      Point `json:"upperLipBottom"`
+	UnderLipTop         Point `json:"underLipTop"`
+	UnderLipBottom      Point `json:"underLipBottom"`
+
+	Extra map[string]Point `json:"-"`
+}
+
+func (l *FaceLandmarks) UnmarshalJSON(data []byte) error {
+	type faceLandmarks FaceLandmarks
+	extra, err := unmarshalWithExtra(data, (*faceLandmarks)(l))
+	if err != nil {
+		return err
+	}
+
+	l.Extra = nil
+	for name, value := range extra {
+		var point Point
+		if err := json.Unmarshal(value, &point); err != nil {
+			return err
+		}
+		if l.Extra == nil {
+			l.Extra = map[string]Point{}
+		}
+		l.Extra[name] = point
+	}
+	return nil
+}
+
+func (l FaceLandmarks) MarshalJSON() ([]byte, error) {
+	type faceLandmarks FaceLandmarks
+	extra := map[string]json.RawMessage{}
+	for name, point := range l.Extra {
+		bytes, err := json.Marshal(point)
+		if err != nil {
+			return nil, err
+		}
+		extra[name] = bytes
+	}
+	return marshalWithExtra(faceLandmarks(l), extra)
+}
+
+// all landmarks (including the ones in Extra) by their names (eg. "noseTip")
+func (l *FaceLandmarks) Points() map[string]Point {
+	points := map[string]Point{}
+	for name, point := range l.pointers() {
+		points[name] = *point
+	}
+	for name, point := range l.Extra {
+		points[name] = point
+	}
+	return points
+}
+
+// pointers to the known landmarks by their names
+func (l *FaceLandmarks) pointers() map[string]*Point {
+	pointers := map[string]*Point{}
+
+	v := reflect.ValueOf(l).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonFieldName(t.Field(i)); name != "" {
+			pointers[name] = v.Field(i).Addr().Interface().(*Point)
+		}
+	}
+	return pointers
+}
+
+// unmarshal data into v, and return the fields which are not in v
+func unmarshalWithExtra(data []byte, v interface{}) (extra map[string]json.RawMessage, err error) {
+	if err = json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		delete(fields, jsonFieldName(t.Field(i)))
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// marshal v with extra fields (fields of v take precedence)
+func marshalWithExtra(v interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	bytes, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return bytes, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(bytes, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, exists := fields[name]; !exists {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// name of the field in json (empty if it is not marshaled)
+func jsonFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("json")
+	if tag == "-" || field.PkgPath != "" {
+		return ""
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name
+	}
+	return field.Name
+}
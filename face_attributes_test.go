@@ -0,0 +1,92 @@
+package cognitive
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFaceAttributes(t *testing.T) {
+	data := `[{
+		"faceId": "face-1",
+		"faceRectangle": {"left": 1, "top": 2, "width": 3, "height": 4},
+		"faceLandmarks": {
+			"pupilLeft": {"x": 10.5, "y": 20.5},
+			"noseTip": {"x": 15.0, "y": 30.0},
+			"underLipBottom": {"x": 15.0, "y": 40.0},
+			"chinTip": {"x": 15.0, "y": 50.0}
+		},
+		"faceAttributes": {
+			"age": 31.5,
+			"gender": "female",
+			"smile": 0.9,
+			"facialHair": {"moustache": 0.1, "beard": 0.2, "sideburns": 0.3},
+			"glasses": "ReadingGlasses",
+			"headPose": {"roll": -1.5, "yaw": 2.5, "pitch": 0.0},
+			"emotion": {"anger": 0.01, "contempt": 0.0, "disgust": 0.0, "fear": 0.0, "happiness": 0.95, "neutral": 0.04, "sadness": 0.0, "surprise": 0.0, "boredom": 0.5},
+			"hair": {"bald": 0.1, "invisible": false}
+		}
+	}, {
+		"faceId": "face-2",
+		"faceRectangle": {"left": 5, "top": 6, "width": 7, "height": 8}
+	}]`
+
+	var results []FaceDetectResult
+	if err := json.Unmarshal([]byte(data), &results); err != nil {
+		t.Fatalf("Failed to unmarshal: %s", err)
+	}
+
+	attributes := results[0].FaceAttributes
+	if attributes.Age != 31.5 || attributes.Gender != GenderFemale || attributes.Glasses != ReadingGlasses || attributes.Smile != 0.9 {
+		t.Errorf("Unexpected attributes: %+v", attributes)
+	}
+	if attributes.FacialHair.Moustache != 0.1 || attributes.FacialHair.Beard != 0.2 || attributes.FacialHair.Sideburns != 0.3 {
+		t.Errorf("Unexpected facial hair: %+v", attributes.FacialHair)
+	}
+	if attributes.HeadPose.Roll != -1.5 || attributes.HeadPose.Yaw != 2.5 || attributes.HeadPose.Pitch != 0.0 {
+		t.Errorf("Unexpected head pose: %+v", attributes.HeadPose)
+	}
+	if !reflect.DeepEqual(attributes.Emotion, EmotionScores{Anger: 0.01, Happiness: 0.95, Neutral: 0.04, Extra: map[string]float64{"boredom": 0.5}}) {
+		t.Errorf("Unexpected emotion: %+v", attributes.Emotion)
+	}
+
+	// unknown fields are kept
+	if _, exists := attributes.Extra["hair"]; !exists || len(attributes.Extra) != 1 {
+		t.Errorf("Unknown attributes should be kept: %+v", attributes.Extra)
+	}
+	if attributes.HeadPose.Extra != nil {
+		t.Errorf("Unexpected extra fields: %+v", attributes.HeadPose.Extra)
+	}
+
+	// landmarks
+	landmarks := results[0].FaceLandmarks
+	if landmarks == nil || landmarks.PupilLeft != (Point{X: 10.5, Y: 20.5}) || landmarks.NoseTip != (Point{X: 15.0, Y: 30.0}) || landmarks.UnderLipBottom != (Point{X: 15.0, Y: 40.0}) {
+		t.Fatalf("Unexpected landmarks: %+v", landmarks)
+	}
+	if landmarks.Extra["chinTip"] != (Point{X: 15.0, Y: 50.0}) {
+		t.Errorf("Unknown landmarks should be kept: %+v", landmarks.Extra)
+	}
+	if points := landmarks.Points(); len(points) != 28 || points["noseTip"] != landmarks.NoseTip || points["chinTip"] != landmarks.Extra["chinTip"] {
+		t.Errorf("Unexpected points (%d): %+v", len(points), points)
+	}
+	if results[1].FaceLandmarks != nil {
+		t.Errorf("Landmarks should be nil when not returned: %+v", results[1].FaceLandmarks)
+	}
+
+	// unknown fields are marshaled back
+	bytes, err := json.Marshal(results[0])
+	if err != nil {
+		t.Fatalf("Failed to marshal: %s", err)
+	}
+	for _, str := range []string{`"hair":{"bald":0.1,"invisible":false}`, `"boredom":0.5`, `"chinTip":{"x":15,"y":50}`, `"gender":"female"`} {
+		if !strings.Contains(string(bytes), str) {
+			t.Errorf("Marshaled result should contain %s: %s", str, string(bytes))
+		}
+	}
+
+	var again FaceDetectResult
+	if err := json.Unmarshal(bytes, &again); err != nil || again.FaceAttributes.Emotion.Happiness != 0.95 || again.FaceAttributes.Emotion.Extra["boredom"] != 0.5 || again.FaceLandmarks.Extra["chinTip"] != landmarks.Extra["chinTip"] {
+		t.Errorf("Unexpected result after round trip: %+v, %v", again, err)
+	}
+}
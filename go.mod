@@ -0,0 +1,3 @@
+module github.com/meinside/ms-cognitive-services-go
+
+go 1.21
@@ -0,0 +1,191 @@
+package cognitive
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// instrumentation of API operations (eg. tracing and metrics with OpenTelemetry)
+//
+// StartOperation is called once for each API call (including each poll of long-running operations),
+// and the returned function is called with its result when it ends (after all retries).
+type Instrumentation interface {
+	StartOperation(ctx context.Context, info OperationInfo) (context.Context, func(result OperationResult))
+}
+
+// information of an API operation
+type OperationInfo struct {
+	Name    string  // name of the operation (eg. "FaceIdentify", "VideoMotionDetectSubmit", "VideoMotionDetectPoll")
+	Service Service // service of the operation
+	Region  string  // region of the API (eg. "westus", empty if unknown)
+	Method  string  // http method
+	Url     string  // url of the request
+}
+
+// result of an API operation
+type OperationResult struct {
+	StatusCode int           // http status code of the last attempt (0 if no response was received)
+	Retries    int           // number of retries
+	Err        error         // error of the last attempt (nil if a response was received)
+	Duration   time.Duration // duration of the operation including all retries
+}
+
+// names of API operations (key: http method and path of the API, with '*' for ids)
+var operationNames = map[string]string{
+	"POST /face/v1.0/detect":                                      "FaceDetect",
+	"POST /face/v1.0/findsimilars":                                "FaceFindSimilar",
+	"POST /face/v1.0/group":                                       "FaceGroup",
+	"POST /face/v1.0/identify":                                    "FaceIdentify",
+	"POST /face/v1.0/verify":                                      "FaceVerify",
+	"POST /face/v1.0/facelists/*/persistedFaces":                  "FaceAddFaceToList",
+	"PUT /face/v1.0/facelists/*":                                  "FaceCreateFaceList",
+	"DELETE /face/v1.0/facelists/*/persistedFaces/*":              "FaceDeleteFace",
+	"DELETE /face/v1.0/facelists/*":                               "FaceDeleteFaceList",
+	"GET /face/v1.0/facelists/*":                                  "FaceGetFaces",
+	"GET /face/v1.0/facelists":                                    "FaceGetLists",
+	"PATCH /face/v1.0/facelists/*":                                "FaceUpdateFaceList",
+	"POST /face/v1.0/persongroups/*/persons/*/persistedFaces":     "FaceAddPersonFace",
+	"POST /face/v1.0/persongroups/*/persons":                      "FaceCreatePerson",
+	"DELETE /face/v1.0/persongroups/*/persons/*":                  "FaceDeletePerson",
+	"DELETE /face/v1.0/persongroups/*/persons/*/persistedFaces/*": "FaceDeletePersonFace",
+	"GET /face/v1.0/persongroups/*/persons/*":                     "FaceGetPerson",
+	"GET /face/v1.0/persongroups/*/persons/*/persistedFaces/*":    "FaceGetPersonFace",
+	"GET /face/v1.0/persongroups/*/persons":                       "FaceGetPersons",
+	"PATCH /face/v1.0/persongroups/*/persons/*":                   "FaceUpdatePerson",
+	"PATCH /face/v1.0/persongroups/*/persons/*/persistedFaces/*":  "FaceUpdatePersonFace",
+	"PUT /face/v1.0/persongroups/*":                               "FaceCreatePersonGroup",
+	"DELETE /face/v1.0/persongroups/*":                            "FaceDeletePersonGroup",
+	"GET /face/v1.0/persongroups/*":                               "FaceGetPersonGroup",
+	"GET /face/v1.0/persongroups/*/training":                      "FaceGetPersonGroupTrainingStatus",
+	"GET /face/v1.0/persongroups":                                 "FaceGetPersonGroups",
+	"POST /face/v1.0/persongroups/*/train":                        "FaceTrainPersonGroup",
+	"PATCH /face/v1.0/persongroups/*":                             "FaceUpdatePersonGroup",
+	"POST /vision/v1.0/analyze":                                   "ComputerVisionAnalyzeImage",
+	"POST /vision/v1.0/describe":                                  "ComputerVisionDescribeImage",
+	"POST /vision/v1.0/generateThumbnail":                         "ComputerVisionGetThumbnail",
+	"GET /vision/v1.0/models":                                     "ComputerVisionGetModels",
+	"POST /vision/v1.0/ocr":                                       "ComputerVisionOcr",
+	"POST /vision/v1.0/models/*/analyze":                          "ComputerVisionDomainSpecificRecognize",
+	"POST /vision/v1.0/recognizeText":                             "ComputerVisionRecognizeHandwrittenSubmit",
+	"POST /vision/v1.0/tag":                                       "ComputerVisionTagImage",
+	"POST /emotion/v1.0/recognize":                                "EmotionRecognizeImage",
+	"POST /emotion/v1.0/recognizeinvideo":                         "EmotionRecognizeVideoSubmit",
+	"POST /video/v1.0/trackface":                                  "VideoFaceDetectTrackSubmit",
+	"POST /video/v1.0/detectmotion":                               "VideoMotionDetectSubmit",
+	"POST /video/v1.0/stabilize":                                  "VideoStabilizeSubmit",
+	"POST /video/v1.0/generatethumbnail":                          "VideoThumbnailSubmit",
+}
+
+// names of polls for long-running operations
+var operationPollNames = map[OperationKind]string{
+	OperationVideoFaceDetectTrack:               "VideoFaceDetectTrackPoll",
+	OperationVideoMotionDetect:                  "VideoMotionDetectPoll",
+	OperationVideoStabilize:                     "VideoStabilizePoll",
+	OperationVideoThumbnail:                     "VideoThumbnailPoll",
+	OperationEmotionRecognizeVideo:              "EmotionRecognizeVideoPoll",
+	OperationComputerVisionRecognizeHandwritten: "ComputerVisionRecognizeHandwrittenPoll",
+}
+
+// key of operation name in request contexts
+type operationNameKey struct{}
+
+// set the name of the API operation in given context
+func withOperationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, operationNameKey{}, name)
+}
+
+// get the name of the API operation of given request (eg. "FaceIdentify", empty if unknown)
+func operationNameOf(req *http.Request) string {
+	if name, ok := req.Context().Value(operationNameKey{}).(string); ok && name != "" {
+		return name
+	}
+
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	for pattern, name := range operationNames {
+		method, path := splitOperationPattern(pattern)
+		if method == req.Method && matchPathSuffix(segments, strings.Split(strings.Trim(path, "/"), "/")) {
+			return name
+		}
+	}
+	return ""
+}
+
+// split pattern of an operation into its method and path
+func splitOperationPattern(pattern string) (method, path string) {
+	if i := strings.Index(pattern, " "); i >= 0 {
+		return pattern[:i], pattern[i+1:]
+	}
+	return "", pattern
+}
+
+// check if the last segments of a path match the pattern's ('*' matches any segment)
+func matchPathSuffix(segments, pattern []string) bool {
+	if len(segments) < len(pattern) {
+		return false
+	}
+
+	segments = segments[len(segments)-len(pattern):]
+	for i, p := range pattern {
+		if p != "*" && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// get the region of given API url (eg. "https://westus.api.cognitive.microsoft.com/..." => "westus")
+func regionOf(req *http.Request) string {
+	if host := req.URL.Hostname(); strings.HasSuffix(host, ".api.cognitive.microsoft.com") {
+		return strings.TrimSuffix(host, ".api.cognitive.microsoft.com")
+	}
+	return ""
+}
+
+// key of attempts counter in request contexts
+type attemptsKey struct{}
+
+// middleware which reports each request to the instrumentation as an API operation
+func instrumentationMiddleware(instrumentation Instrumentation) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx, end := instrumentation.StartOperation(req.Context(), OperationInfo{
+				Name:    operationNameOf(req),
+				Service: serviceOf(req.URL),
+				Region:  regionOf(req),
+				Method:  req.Method,
+				Url:     req.URL.String(),
+			})
+
+			var attempts int32
+			started := time.Now()
+			resp, err := next(req.WithContext(context.WithValue(ctx, attemptsKey{}, &attempts)))
+
+			result := OperationResult{
+				Err:      err,
+				Duration: time.Since(started),
+			}
+			if resp != nil {
+				result.StatusCode = resp.StatusCode
+			}
+			if attempts > 1 {
+				result.Retries = int(attempts) - 1
+			}
+			end(result)
+
+			return resp, err
+		}
+	}
+}
+
+// middleware which counts attempts of requests for the instrumentation
+func countAttemptsMiddleware(next RoundTripFunc) RoundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		if attempts, ok := req.Context().Value(attemptsKey{}).(*int32); ok {
+			atomic.AddInt32(attempts, 1)
+		}
+		return next(req)
+	}
+}
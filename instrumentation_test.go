@@ -0,0 +1,129 @@
+package cognitive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// instrumentation which records operations
+type testInstrumentation struct {
+	sync.Mutex
+
+	infos   []OperationInfo
+	results []OperationResult
+}
+
+type testInstrumentationKey struct{}
+
+func (i *testInstrumentation) StartOperation(ctx context.Context, info OperationInfo) (context.Context, func(OperationResult)) {
+	i.Lock()
+	i.infos = append(i.infos, info)
+	i.Unlock()
+
+	return context.WithValue(ctx, testInstrumentationKey{}, info.Name), func(result OperationResult) {
+		i.Lock()
+		i.results = append(i.results, result)
+		i.Unlock()
+	}
+}
+
+func TestInstrumentation(t *testing.T) {
+	var identifies, polls int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/face/v1.0/identify":
+			if atomic.AddInt32(&identifies, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, `[]`)
+		case "/video/v1.0/stabilize":
+			w.Header().Set("Operation-Location", server.URL+"/video/v1.0/operations/1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/video/v1.0/operations/1":
+			if atomic.AddInt32(&polls, 1) < 2 {
+				io.WriteString(w, `{"status":"Running","progress":50.0}`)
+			} else {
+				io.WriteString(w, `{"status":"Succeeded","progress":100.0,"resourceLocation":"http://localhost/result.mp4"}`)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	instrumentation := &testInstrumentation{}
+	client := &Client{
+		BaseUrl:     server.URL,
+		PollOptions: testPollOptions,
+		Retry:       &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Middlewares: []Middleware{
+			func(next RoundTripFunc) RoundTripFunc {
+				return func(req *http.Request) (*http.Response, error) {
+					// context returned from the instrumentation should be propagated
+					if name, _ := req.Context().Value(testInstrumentationKey{}).(string); name == "" {
+						t.Errorf("Context from the instrumentation was not propagated: %s", req.URL)
+					}
+					return next(req)
+				}
+			},
+		},
+		Instrumentation: instrumentation,
+	}
+
+	if _, err := client.FaceIdentify(context.Background(), WestUS, "test-key", []string{"face-1"}, "group-1", 1, 0.5); err != nil {
+		t.Errorf("FaceIdentify() failed: %s", err)
+	}
+	if _, err := client.VideoStabilize(context.Background(), "test-key", "http://localhost/video.mp4", nil); err != nil {
+		t.Errorf("VideoStabilize() failed: %s", err)
+	}
+	if _, err := client.FaceGetPersonGroup(context.Background(), WestUS, "test-key", "not-existing"); !IsNotFound(err) {
+		t.Errorf("FaceGetPersonGroup() should fail with not found, got: %v", err)
+	}
+
+	expected := []struct {
+		name       string
+		service    Service
+		statusCode int
+		retries    int
+	}{
+		{"FaceIdentify", ServiceFace, http.StatusOK, 2},
+		{"VideoStabilizeSubmit", ServiceVideo, http.StatusAccepted, 0},
+		{"VideoStabilizePoll", ServiceVideo, http.StatusOK, 0},
+		{"VideoStabilizePoll", ServiceVideo, http.StatusOK, 0},
+		{"FaceGetPersonGroup", ServiceFace, http.StatusNotFound, 0},
+	}
+	if len(instrumentation.infos) != len(expected) || len(instrumentation.results) != len(expected) {
+		t.Fatalf("Unexpected operations: %+v, results: %+v", instrumentation.infos, instrumentation.results)
+	}
+	for i, e := range expected {
+		info, result := instrumentation.infos[i], instrumentation.results[i]
+		if info.Name != e.name || info.Service != e.service || result.StatusCode != e.statusCode || result.Retries != e.retries || result.Err != nil || result.Duration <= 0 {
+			t.Errorf("Unexpected operation #%d: %+v, %+v", i, info, result)
+		}
+	}
+}
+
+func TestOperationNameAndRegion(t *testing.T) {
+	for url, expected := range map[string][2]string{
+		"POST https://westus.api.cognitive.microsoft.com/face/v1.0/detect":                                   {"FaceDetect", "westus"},
+		"GET https://eastus2.api.cognitive.microsoft.com/face/v1.0/persongroups/group-1/persons/person-1":    {"FaceGetPerson", "eastus2"},
+		"DELETE https://westus.api.cognitive.microsoft.com/face/v1.0/facelists/list-1/persistedFaces/face-1": {"FaceDeleteFace", "westus"},
+		"POST http://localhost:8080/proxy/vision/v1.0/models/celebrities/analyze":                            {"ComputerVisionDomainSpecificRecognize", ""},
+		"GET https://westus.api.cognitive.microsoft.com/face/v1.0/persongroups/group-1/training":             {"FaceGetPersonGroupTrainingStatus", "westus"},
+		"GET https://westus.api.cognitive.microsoft.com/unknown/v1.0/something":                              {"", "westus"},
+	} {
+		method, rawurl := splitOperationPattern(url)
+		req, _ := http.NewRequest(method, rawurl, nil)
+		if name, region := operationNameOf(req), regionOf(req); name != expected[0] || region != expected[1] {
+			t.Errorf("Unexpected operation name and region of %s: %s, %s", url, name, region)
+		}
+	}
+}
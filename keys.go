@@ -0,0 +1,207 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// subscription key of a resource
+type SubscriptionKey struct {
+	Location ApiLocation // location of the resource (eg. WestUS, ignored when Client.Endpoint is set)
+	Key      string      // subscription key
+}
+
+// provider of subscription keys
+//
+// Keys() returns the keys to try for a request in order; the next one is tried when the previous one failed with 401, 403, or 429
+type KeyProvider interface {
+	Keys() []SubscriptionKey
+}
+
+// modes of KeyRing
+type KeyRingMode int
+
+const (
+	KeyRingFailover   KeyRingMode = iota // always try keys from the first one (eg. primary => secondary)
+	KeyRingRoundRobin                    // start from the next key for each request, for spreading quotas over resources
+)
+
+// key provider with multiple subscription keys, safe for concurrent use
+//
+// keys can be rotated with SetKeys() or ReplaceKey() without recreating clients
+type KeyRing struct {
+	mode KeyRingMode
+
+	mu   sync.Mutex
+	keys []SubscriptionKey
+	next int
+}
+
+// create a new key ring with given mode and keys
+func NewKeyRing(mode KeyRingMode, keys ...SubscriptionKey) *KeyRing {
+	return &KeyRing{
+		mode: mode,
+		keys: append([]SubscriptionKey{}, keys...),
+	}
+}
+
+// create a new key ring with primary and secondary keys of a resource
+func NewPrimarySecondaryKeys(location ApiLocation, primary, secondary string) *KeyRing {
+	return NewKeyRing(KeyRingFailover, SubscriptionKey{location, primary}, SubscriptionKey{location, secondary})
+}
+
+// keys to try for a request in order (implements KeyProvider)
+func (r *KeyRing) Keys() (keys []SubscriptionKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keys = make([]SubscriptionKey, 0, len(r.keys))
+	if len(r.keys) == 0 {
+		return keys
+	}
+
+	start := 0
+	if r.mode == KeyRingRoundRobin {
+		start = r.next % len(r.keys)
+		r.next = (start + 1) % len(r.keys)
+	}
+	keys = append(keys, r.keys[start:]...)
+	return append(keys, r.keys[:start]...)
+}
+
+// all keys in order, without moving the round-robin cursor
+func (r *KeyRing) all() []SubscriptionKey {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]SubscriptionKey{}, r.keys...)
+}
+
+// keys of given provider (for KeyRing, without moving its round-robin cursor)
+func keysOf(provider KeyProvider) []SubscriptionKey {
+	if ring, ok := provider.(*KeyRing); ok {
+		return ring.all()
+	}
+	return provider.Keys()
+}
+
+// replace all keys
+func (r *KeyRing) SetKeys(keys ...SubscriptionKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.keys = append([]SubscriptionKey{}, keys...)
+}
+
+// replace a key with a new one (eg. after regenerating it), returns false if the old key was not found
+func (r *KeyRing) ReplaceKey(oldKey, newKey string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, k := range r.keys {
+		if k.Key == oldKey {
+			r.keys[i].Key = newKey
+			return true
+		}
+	}
+	return false
+}
+
+// errors returned from WithKeys()
+var (
+	ErrNoKeys = errors.New("no subscription keys")
+)
+
+// call fn with the keys of given provider in order, until it succeeds or fails with an error which is not from the key
+//
+// (fails over to the next key on 401, 403, and 429 responses, or client-side rate limits)
+//
+// for requests with media or long-running operations, use WithKeysForMedia() instead
+func WithKeys(ctx context.Context, provider KeyProvider, fn func(location ApiLocation, key string) error) (err error) {
+	keys := provider.Keys()
+	if len(keys) == 0 {
+		return ErrNoKeys
+	}
+
+	for _, k := range keys {
+		if err = fn(k.Location, k.Key); err == nil || !shouldFailover(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// call fn with the keys of given provider in order like WithKeys(), for requests with media (eg. images or videos)
+//
+// media from an io.Reader is rewound before the next key if it is an io.ReadSeeker, and is not failed over at all if it cannot be rewound;
+// for long-running operations, only their submission is failed over (errors while waiting for their results are returned as they are,
+// so that the billed jobs are not submitted again)
+//
+// fn should send its requests with the given ctx
+func WithKeysForMedia(ctx context.Context, provider KeyProvider, media interface{}, fn func(ctx context.Context, location ApiLocation, key string) error) (err error) {
+	keys := provider.Keys()
+	if len(keys) == 0 {
+		return ErrNoKeys
+	}
+
+	// offset of the reader, for rewinding it
+	var reader io.Reader
+	if m, e := mediaOf(media); e == nil {
+		reader = m.reader
+	}
+	seeker, rewindable := reader.(io.Seeker)
+	var offset int64
+	if rewindable {
+		var e error
+		offset, e = seeker.Seek(0, io.SeekCurrent)
+		rewindable = e == nil
+	}
+
+	for i, k := range keys {
+		if i > 0 && reader != nil {
+			if !rewindable {
+				return err
+			}
+			if _, e := seeker.Seek(offset, io.SeekStart); e != nil {
+				return err
+			}
+		}
+
+		submission := &submission{}
+		if err = fn(context.WithValue(ctx, submissionKey{}, submission), k.Location, k.Key); err == nil || submission.done || !shouldFailover(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// submission of a long-running operation, in the context of WithKeysForMedia()
+type submission struct {
+	done bool // submitted, and waiting for its result
+}
+
+type submissionKey struct{}
+
+// mark the submission in ctx (if any) as done, so that errors while waiting for the result are not failed over
+func markSubmitted(ctx context.Context) {
+	if s, ok := ctx.Value(submissionKey{}).(*submission); ok {
+		s.done = true
+	}
+}
+
+// check if the request should be tried again with another key, after failing with given error
+func shouldFailover(err error) bool {
+	if errors.Is(err, ErrRateLimitExceeded) || errors.Is(err, ErrMonthlyLimitExceeded) {
+		return true
+	}
+	return isApiError(err, func(e *ApiError) bool {
+		switch e.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+			return true
+		}
+		return false
+	})
+}
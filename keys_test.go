@@ -0,0 +1,230 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestKeyRing(t *testing.T) {
+	a, b, c := SubscriptionKey{WestUS, "key-a"}, SubscriptionKey{EastUS2, "key-b"}, SubscriptionKey{WestEurope, "key-c"}
+
+	// failover
+	ring := NewKeyRing(KeyRingFailover, a, b, c)
+	for i := 0; i < 2; i++ {
+		if keys := ring.Keys(); !reflect.DeepEqual(keys, []SubscriptionKey{a, b, c}) {
+			t.Errorf("Unexpected keys: %+v", keys)
+		}
+	}
+
+	// round-robin
+	ring = NewKeyRing(KeyRingRoundRobin, a, b, c)
+	for _, expected := range [][]SubscriptionKey{{a, b, c}, {b, c, a}, {c, a, b}, {a, b, c}} {
+		if keys := ring.Keys(); !reflect.DeepEqual(keys, expected) {
+			t.Errorf("Unexpected keys: %+v, expected: %+v", keys, expected)
+		}
+	}
+
+	// rotation
+	if !ring.ReplaceKey("key-b", "key-b2") || ring.ReplaceKey("not-existing", "key-d") {
+		t.Errorf("ReplaceKey() returned unexpected results")
+	}
+	if keys := ring.Keys(); keys[0].Key != "key-b2" { // round-robin continues from the second key
+		t.Errorf("Key was not replaced: %+v", keys)
+	}
+	ring.SetKeys()
+	if keys := ring.Keys(); len(keys) != 0 {
+		t.Errorf("Keys were not replaced: %+v", keys)
+	}
+	if err := WithKeys(context.Background(), ring, func(ApiLocation, string) error { return nil }); err != ErrNoKeys {
+		t.Errorf("WithKeys() should fail with ErrNoKeys, got: %v", err)
+	}
+}
+
+func TestWithKeys(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Ocp-Apim-Subscription-Key") {
+		case "revoked-key":
+			w.WriteHeader(http.StatusUnauthorized)
+			io.WriteString(w, `{"error":{"code":"Unspecified","message":"Access denied due to invalid subscription key."}}`)
+		case "throttled-key":
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "valid-key":
+			if r.URL.Path == "/face/v1.0/persongroups/not-existing" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, `[]`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+	ring := NewKeyRing(KeyRingFailover,
+		SubscriptionKey{WestUS, "revoked-key"},
+		SubscriptionKey{WestUS, "throttled-key"},
+		SubscriptionKey{EastUS2, "valid-key"},
+	)
+
+	var tried []string
+	err := WithKeys(context.Background(), ring, func(location ApiLocation, key string) (err error) {
+		tried = append(tried, key)
+		_, err = client.FaceGetLists(context.Background(), location, key)
+		return err
+	})
+	if err != nil || !reflect.DeepEqual(tried, []string{"revoked-key", "throttled-key", "valid-key"}) {
+		t.Errorf("WithKeys() = %v, tried: %v", err, tried)
+	}
+
+	// should not fail over on other errors
+	tried = nil
+	ring.SetKeys(SubscriptionKey{WestUS, "valid-key"}, SubscriptionKey{WestUS, "revoked-key"})
+	err = WithKeys(context.Background(), ring, func(location ApiLocation, key string) (err error) {
+		tried = append(tried, key)
+		_, err = client.FaceGetPersonGroup(context.Background(), location, key, "not-existing")
+		return err
+	})
+	if !IsNotFound(err) || len(tried) != 1 {
+		t.Errorf("WithKeys() = %v, tried: %v", err, tried)
+	}
+
+	// all keys failed
+	ring.SetKeys(SubscriptionKey{WestUS, "revoked-key"}, SubscriptionKey{WestUS, "throttled-key"})
+	err = WithKeys(context.Background(), ring, func(location ApiLocation, key string) (err error) {
+		_, err = client.FaceGetLists(context.Background(), location, key)
+		return err
+	})
+	if !IsRateLimited(err) {
+		t.Errorf("WithKeys() should fail with the last error, got: %v", err)
+	}
+}
+
+func TestResumeOperationWithKeys(t *testing.T) {
+	// two resources, each of which accepts its own key only
+	newResource := func(key string) *httptest.Server {
+		return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Ocp-Apim-Subscription-Key") != key {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			io.WriteString(w, `{"status":"Running","progress":50.0}`)
+		}))
+	}
+	resourceA, resourceB := newResource("key-a"), newResource("key-b")
+	defer resourceA.Close()
+	defer resourceB.Close()
+
+	client := &Client{HttpClient: routingHttpClient(map[ApiLocation]*httptest.Server{WestUS: resourceA, WestEurope: resourceB})}
+	ring := NewKeyRing(KeyRingRoundRobin,
+		SubscriptionKey{WestUS, "key-a"},
+		SubscriptionKey{WestEurope, "key-b"},
+	)
+
+	// operation of the second resource
+	op, err := client.ResumeOperationWithKeys(ring, OperationHandle{Kind: OperationVideoStabilize, Location: "https://westeurope.api.cognitive.microsoft.com/video/v1.0/operations/1"})
+	if err != nil {
+		t.Fatalf("ResumeOperationWithKeys() failed: %s", err)
+	}
+	if done, err := op.Poll(context.Background()); done || err != nil || op.Progress() != 50.0 {
+		t.Errorf("Poll() = %t, %v", done, err)
+	}
+
+	// round-robin cursor is not moved
+	if keys := ring.Keys(); keys[0].Key != "key-a" {
+		t.Errorf("Round-robin cursor was moved: %+v", keys)
+	}
+
+	// operation of an unknown resource
+	if _, err := client.ResumeOperationWithKeys(ring, OperationHandle{Kind: OperationVideoStabilize, Location: "https://eastus.api.cognitive.microsoft.com/video/v1.0/operations/1"}); err == nil {
+		t.Errorf("ResumeOperationWithKeys() should fail for an operation of an unknown resource")
+	}
+
+	// failover to the secondary key of the same resource
+	ring = NewPrimarySecondaryKeys(WestEurope, "revoked-key", "key-b")
+	if op, err = client.ResumeOperationWithKeys(ring, OperationHandle{Kind: OperationVideoStabilize, Location: "https://westeurope.api.cognitive.microsoft.com/video/v1.0/operations/1"}); err != nil {
+		t.Fatalf("ResumeOperationWithKeys() failed: %s", err)
+	}
+	if done, err := op.Poll(context.Background()); done || err != nil {
+		t.Errorf("Poll() = %t, %v", done, err)
+	}
+}
+
+func TestWithKeysForMedia(t *testing.T) {
+	var submits, polls int32
+	var bodies []string
+	var bodiesLock sync.Mutex
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/video/v1.0/stabilize":
+			atomic.AddInt32(&submits, 1)
+			w.Header().Set("Operation-Location", server.URL+"/video/v1.0/operations/1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/video/v1.0/operations/1":
+			atomic.AddInt32(&polls, 1)
+			w.WriteHeader(http.StatusUnauthorized) // eg. the key was revoked while waiting
+		case "/face/v1.0/detect":
+			body, _ := io.ReadAll(r.Body)
+			bodiesLock.Lock()
+			bodies = append(bodies, string(body))
+			bodiesLock.Unlock()
+			if r.Header.Get("Ocp-Apim-Subscription-Key") != "valid-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			io.WriteString(w, `[]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL, PollOptions: testPollOptions}
+	ring := NewKeyRing(KeyRingFailover,
+		SubscriptionKey{WestUS, "revoked-key"},
+		SubscriptionKey{WestUS, "valid-key"},
+	)
+	detect := func(image interface{}) error {
+		return WithKeysForMedia(context.Background(), ring, image, func(ctx context.Context, location ApiLocation, key string) (err error) {
+			_, err = client.FaceDetectWithOptions(ctx, location, key, image)
+			return err
+		})
+	}
+
+	// rewound before the next key
+	image := testPng(t, 64, 64)
+	if err := detect(bytes.NewReader(image)); err != nil {
+		t.Errorf("WithKeysForMedia() failed: %s", err)
+	}
+	if !reflect.DeepEqual(bodies, []string{string(image), string(image)}) {
+		t.Errorf("Unexpected number of requests: %d", len(bodies))
+	}
+
+	// not failed over when it cannot be rewound
+	bodies = nil
+	if err := detect(io.MultiReader(bytes.NewReader(image))); !isApiError(err, func(e *ApiError) bool { return e.StatusCode == http.StatusUnauthorized }) {
+		t.Errorf("WithKeysForMedia() should fail with the error of the first key, got: %v", err)
+	}
+	if len(bodies) != 1 {
+		t.Errorf("Unexpected number of requests: %d", len(bodies))
+	}
+
+	// only the submission of a long-running operation is failed over
+	ring.SetKeys(SubscriptionKey{WestUS, "key-a"}, SubscriptionKey{WestUS, "key-b"})
+	err := WithKeysForMedia(context.Background(), ring, "http://localhost/video.mp4", func(ctx context.Context, location ApiLocation, key string) (err error) {
+		_, err = client.VideoStabilizeWithOptions(ctx, location, key, "http://localhost/video.mp4")
+		return err
+	})
+	if !isApiError(err, func(e *ApiError) bool { return e.StatusCode == http.StatusUnauthorized }) {
+		t.Errorf("WithKeysForMedia() should fail with the error while waiting, got: %v", err)
+	}
+	if submits != 1 || polls != 1 {
+		t.Errorf("Unexpected number of requests: %d submits, %d polls", submits, polls)
+	}
+}
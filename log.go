@@ -0,0 +1,176 @@
+package cognitive
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// logger for requests and responses
+//
+// *slog.Logger satisfies this interface (eg. &Client{Logger: slog.Default()}).
+//
+// requests and responses are logged with slog.LevelDebug, retries with slog.LevelInfo,
+// error responses with slog.LevelWarn, and failed requests with slog.LevelError.
+type Logger interface {
+	Log(ctx context.Context, level slog.Level, msg string, args ...any)
+}
+
+// options for logging requests and responses
+type LogOptions struct {
+	MaxBodyLength int      // maximum length of logged bodies in bytes (default: DefaultLogMaxBodyLength, negative: bodies are not logged)
+	RedactHeaders []string // http headers to be redacted, in addition to Ocp-Apim-Subscription-Key and Authorization
+}
+
+// default values of LogOptions
+const (
+	DefaultLogMaxBodyLength = 1024
+)
+
+// http headers which are always redacted in logs
+var redactedHeaders = []string{"Ocp-Apim-Subscription-Key", "Authorization"}
+
+// logger for the client (nil if logging is disabled)
+func (c *Client) logger() Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	if IsVerbose {
+		return slog.New(slog.NewTextHandler(log.Writer(), &slog.HandlerOptions{Level: slog.LevelDebug}))
+	}
+	return nil
+}
+
+// log a message with the client's logger
+func (c *Client) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if logger := c.logger(); logger != nil {
+		logger.Log(ctx, level, msg, args...)
+	}
+}
+
+// middleware which logs each attempt of requests with given logger and options (options can be nil)
+//
+// should be placed inside RetryMiddleware() for logging every attempt
+func LoggingMiddleware(logger Logger, options *LogOptions) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			started := time.Now()
+			resp, err := next(req)
+			options.logAttempt(logger, req, resp, err, time.Since(started))
+
+			return resp, err
+		}
+	}
+}
+
+// log an attempt of given request
+func (o *LogOptions) logAttempt(logger Logger, req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	args := []any{
+		"method", req.Method,
+		"url", req.URL.String(),
+		"attempt", attemptOf(req.Context()),
+		"duration", duration,
+		"headers", o.redactHeaders(req.Header),
+	}
+	if req.ContentLength > 0 {
+		args = append(args, "size", req.ContentLength)
+	}
+
+	if err != nil {
+		logger.Log(req.Context(), slog.LevelError, "request failed", append(args, "error", err)...)
+		return
+	}
+
+	args = append(args, "status", resp.StatusCode)
+	if id := requestIdOf(resp.Header); id != "" {
+		args = append(args, "request_id", id)
+	}
+
+	level := slog.LevelDebug
+	if resp.StatusCode >= 400 {
+		level = slog.LevelWarn
+	}
+	logger.Log(req.Context(), level, "request", args...)
+}
+
+// log a retry of a request
+func (c *Client) logRetry(ctx context.Context, event RetryEvent) {
+	c.log(ctx, slog.LevelInfo, "retrying request", "method", event.Method, "url", event.Url, "attempt", event.Attempt, "status", event.StatusCode, "delay", event.Delay)
+}
+
+// log given request body
+func (c *Client) logRequestBody(ctx context.Context, method, url string, body []byte) {
+	if c.logger() != nil && c.LogOptions.maxBodyLength() >= 0 {
+		c.log(ctx, slog.LevelDebug, "request body", "method", strings.ToUpper(method), "url", url, "body", c.LogOptions.truncateBody(body))
+	}
+}
+
+// log the body of given response
+func (c *Client) logResponseBody(resp *http.Response, body []byte) {
+	if c.logger() == nil || c.LogOptions.maxBodyLength() < 0 {
+		return
+	}
+
+	// request of the response can be nil (eg. a response built by a middleware)
+	ctx := context.Background()
+	args := []any{}
+	if resp.Request != nil {
+		ctx = resp.Request.Context()
+		args = append(args, "url", resp.Request.URL.String())
+	}
+	args = append(args,
+		"status", resp.StatusCode,
+		"body", c.LogOptions.truncateBody(body),
+	)
+	if id := requestIdOf(resp.Header); id != "" {
+		args = append(args, "request_id", id)
+	}
+	if location := resp.Header.Get("Operation-Location"); location != "" {
+		args = append(args, "operation_location", location)
+	}
+	c.log(ctx, slog.LevelDebug, "response body", args...)
+}
+
+// maximum length of logged bodies
+func (o *LogOptions) maxBodyLength() int {
+	if o != nil && o.MaxBodyLength != 0 {
+		return o.MaxBodyLength
+	}
+	return DefaultLogMaxBodyLength
+}
+
+// copy given http headers, with sensitive values redacted
+func (o *LogOptions) redactHeaders(header http.Header) map[string]string {
+	redacted := map[string]string{}
+	for k, v := range header {
+		redacted[k] = strings.Join(v, ", ")
+	}
+
+	headers := redactedHeaders
+	if o != nil {
+		headers = append(append([]string{}, headers...), o.RedactHeaders...)
+	}
+	for _, h := range headers {
+		if _, exists := redacted[http.CanonicalHeaderKey(h)]; exists {
+			redacted[http.CanonicalHeaderKey(h)] = "REDACTED"
+		}
+	}
+	return redacted
+}
+
+// truncate given body for logging (binary bodies are logged with their sizes only)
+func (o *LogOptions) truncateBody(body []byte) string {
+	if !utf8.Valid(body) {
+		return fmt.Sprintf("(%d bytes)", len(body))
+	}
+
+	if max := o.maxBodyLength(); len(body) > max {
+		return fmt.Sprintf("%s... (%d bytes)", strings.ToValidUTF8(string(body[:max]), ""), len(body))
+	}
+	return string(body)
+}
@@ -0,0 +1,71 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClientLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Apim-Request-Id", "request-1")
+
+		if r.URL.Path == "/face/v1.0/persongroups/not-existing" {
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"code":"PersonGroupNotFound","message":"Person group is not found."}}`)
+			return
+		}
+		io.WriteString(w, `{"personGroupId":"group-1","name":"`+strings.Repeat("x", 100)+`"}`)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	client := &Client{
+		BaseUrl:    server.URL,
+		Logger:     slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		LogOptions: &LogOptions{MaxBodyLength: 50, RedactHeaders: []string{"X-Secret"}},
+		Headers:    map[string]string{"X-Secret": "secret-value"},
+	}
+
+	if _, err := client.FaceGetPersonGroup(context.Background(), WestUS, "secret-key", "group-1"); err != nil {
+		t.Errorf("FaceGetPersonGroup() failed: %s", err)
+	}
+	if _, err := client.FaceGetPersonGroup(context.Background(), WestUS, "secret-key", "not-existing"); !IsNotFound(err) {
+		t.Errorf("FaceGetPersonGroup() should fail with not found, got: %v", err)
+	}
+
+	logs := buf.String()
+	for _, expected := range []string{
+		"level=DEBUG msg=request ",
+		"level=WARN msg=request ",
+		"request_id=request-1",
+		"status=404",
+		"duration=",
+		"REDACTED",
+		"... (137 bytes)", // truncated body
+	} {
+		if !strings.Contains(logs, expected) {
+			t.Errorf("Logs should contain %q:\n%s", expected, logs)
+		}
+	}
+	for _, unexpected := range []string{"secret-key", "secret-value", strings.Repeat("x", 100)} {
+		if strings.Contains(logs, unexpected) {
+			t.Errorf("Logs should not contain %q:\n%s", unexpected, logs)
+		}
+	}
+
+	// bodies are not logged
+	buf.Reset()
+	client.LogOptions.MaxBodyLength = -1
+	if _, err := client.FaceGetPersonGroup(context.Background(), WestUS, "secret-key", "group-1"); err != nil {
+		t.Errorf("FaceGetPersonGroup() failed: %s", err)
+	}
+	if logs := buf.String(); strings.Contains(logs, "body") || !strings.Contains(logs, "msg=request") {
+		t.Errorf("Unexpected logs without bodies:\n%s", logs)
+	}
+}
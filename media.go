@@ -0,0 +1,456 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // for decoding gif headers
+	_ "image/jpeg" // for decoding jpeg headers
+	_ "image/png"  // for decoding png headers
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// kinds of media
+type MediaKind int
+
+const (
+	MediaImage MediaKind = iota
+	MediaVideo
+)
+
+func (k MediaKind) String() string {
+	if k == MediaVideo {
+		return "video"
+	}
+	return "image"
+}
+
+// formats of media
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatBMP  = "bmp"
+	FormatMP4  = "mp4"
+	FormatMOV  = "mov"
+	FormatWMV  = "wmv"
+)
+
+// limits of media for an endpoint
+type mediaLimit struct {
+	minSize   int64    // minimum size in bytes (0: no limit)
+	maxSize   int64    // maximum size in bytes (0: no limit)
+	minWidth  int      // minimum width in pixels (0: no limit)
+	minHeight int      // minimum height in pixels (0: no limit)
+	maxWidth  int      // maximum width in pixels (0: no limit)
+	maxHeight int      // maximum height in pixels (0: no limit)
+	formats   []string // supported formats
+}
+
+// check if the dimensions of images should be checked
+func (l mediaLimit) hasDimensions() bool {
+	return l.minWidth > 0 || l.minHeight > 0 || l.maxWidth > 0 || l.maxHeight > 0
+}
+
+// limits of images for endpoints, in addition to the limits of their services (key: suffix of the API url's path)
+var imageLimits = map[string]mediaLimit{
+	"/face/v1.0/detect": {
+		minSize:   1024,            // 1KB
+		maxSize:   4 * 1024 * 1024, // 4MB
+		minWidth:  36,
+		minHeight: 36,
+		maxWidth:  4096,
+		maxHeight: 4096,
+	},
+	"/vision/v1.0/analyze": {
+		maxSize:   4 * 1024 * 1024, // 4MB
+		minWidth:  50,
+		minHeight: 50,
+		maxWidth:  4096,
+		maxHeight: 4096,
+	},
+	"/vision/v1.0/ocr": {
+		maxSize:   4 * 1024 * 1024, // 4MB
+		minWidth:  40,
+		minHeight: 40,
+		maxWidth:  3200,
+		maxHeight: 3200,
+	},
+	"/vision/v1.0/recognizeText": {
+		maxSize:   4 * 1024 * 1024, // 4MB
+		minWidth:  40,
+		minHeight: 40,
+		maxWidth:  3200,
+		maxHeight: 3200,
+	},
+	"/emotion/v1.0/recognize": {
+		maxSize:   4 * 1024 * 1024, // 4MB
+		minWidth:  36,
+		minHeight: 36,
+		maxWidth:  4096,
+		maxHeight: 4096,
+	},
+}
+
+// get the limits of media for given API url
+func mediaLimitOf(apiUrl string, kind MediaKind) mediaLimit {
+	if kind == MediaVideo {
+		return mediaLimit{
+			maxSize: 100 * 1024 * 1024, // 100MB
+			formats: []string{FormatMP4, FormatMOV, FormatWMV},
+		}
+	}
+
+	u, _ := url.Parse(apiUrl)
+	if u == nil {
+		u = &url.URL{}
+	}
+
+	for suffix, limit := range imageLimits {
+		if strings.HasSuffix(u.Path, suffix) {
+			limit.formats = []string{FormatJPEG, FormatPNG, FormatGIF, FormatBMP}
+			return limit
+		}
+	}
+
+	limit := mediaLimit{
+		maxSize: 4 * 1024 * 1024, // 4MB
+		formats: []string{FormatJPEG, FormatPNG, FormatGIF, FormatBMP},
+	}
+	if serviceOf(u) == ServiceFace {
+		limit.minSize = 1024 // 1KB
+	}
+	return limit
+}
+
+// errors wrapped in MediaError, which can be checked with errors.Is()
+var (
+	ErrMediaEmpty       = errors.New("media is empty")
+	ErrMediaTooLarge    = errors.New("media is too large")
+	ErrMediaTooSmall    = errors.New("media is too small")
+	ErrMediaFormat      = errors.New("media format is not supported")
+	ErrMediaDimensions  = errors.New("image dimensions are out of range")
+	ErrMediaUndecodable = errors.New("image header could not be decoded")
+)
+
+// error returned when given media is not acceptable, before any request is sent
+type MediaError struct {
+	Media   string // description of the media (eg. url, file path)
+	Message string // reason of the error
+	Err     error  // underlying error (can be nil)
+}
+
+func (e *MediaError) Error() string {
+	if e.Media == "" {
+		return "invalid media; " + e.Message
+	}
+	return "invalid media (" + e.Media + "); " + e.Message
+}
+
+func (e *MediaError) Unwrap() error {
+	return e.Err
+}
+
+// image or video to be sent to the APIs
+//
+// should be created with FromURL(), FromBytes(), FromFile(), or FromReader()
+type Media struct {
+	url    string
+	data   []byte
+	path   string
+	reader io.Reader
+	size   int64 // negative if unknown
+	format string
+	width  int
+	height int
+}
+
+// media at given url
+func FromURL(url string) Media {
+	return Media{url: url, size: -1}
+}
+
+// media in given bytes array
+func FromBytes(data []byte) Media {
+	return Media{data: data, size: int64(len(data))}
+}
+
+// media in given local file, which will be streamed without being loaded into memory
+func FromFile(path string) Media {
+	return Media{path: path, size: -1}
+}
+
+// media from given reader, which will be streamed
+//
+// size : size in bytes (negative if unknown)
+func FromReader(reader io.Reader, size int64) Media {
+	switch r := reader.(type) {
+	case nil:
+		return Media{size: -1}
+	case SizedReader:
+		reader = r.Reader
+	case *SizedReader:
+		reader = r.Reader
+	}
+	return Media{reader: reader, size: size}
+}
+
+// check if the media is at a url
+func (m Media) IsURL() bool {
+	return m.url != ""
+}
+
+// size of the media in bytes (negative if unknown, eg. media at a url)
+func (m Media) Size() int64 {
+	return m.size
+}
+
+// format of the media (eg. FormatJPEG), detected while validating (empty if unknown)
+func (m Media) Format() string {
+	return m.format
+}
+
+// width and height of the image in pixels, decoded while validating (0 if unknown, eg. media at a url)
+func (m Media) Dimensions() (width, height int) {
+	return m.width, m.height
+}
+
+// description of the media for errors and logs
+func (m Media) String() string {
+	switch {
+	case m.url != "":
+		return m.url
+	case m.path != "":
+		return m.path
+	case m.data != nil:
+		return fmt.Sprintf("%d bytes", len(m.data))
+	case m.reader != nil:
+		if m.size >= 0 {
+			return fmt.Sprintf("stream of %d bytes", m.size)
+		}
+		return "stream"
+	}
+	return ""
+}
+
+// convert given argument (Media, string(url), []byte, File, or io.Reader) to Media
+func mediaOf(arg interface{}) (media Media, err error) {
+	switch a := arg.(type) {
+	case Media:
+		media = a
+	case *Media:
+		if a != nil {
+			media = *a
+		}
+	case string:
+		media = FromURL(a)
+	case []byte:
+		media = FromBytes(a)
+	case File:
+		media = FromFile(string(a))
+	case io.Reader:
+		media = FromReader(a, readerSize(a))
+	default:
+		return media, &MediaError{Message: fmt.Sprintf("given parameter type (%T) is not supported", arg)}
+	}
+
+	if media.url == "" && media.data == nil && media.path == "" && media.reader == nil {
+		return media, &MediaError{Message: "media is empty", Err: ErrMediaEmpty}
+	}
+	return media, nil
+}
+
+// validate the media against given limits, detecting its size, format, and dimensions
+func (m *Media) validate(limit mediaLimit, kind MediaKind) (err error) {
+	if m.url != "" {
+		if u, err := url.Parse(m.url); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return &MediaError{Media: m.url, Message: "not a valid http(s) url", Err: err}
+		}
+		return nil
+	}
+
+	// read the header for detecting its format
+	var head []byte
+	if err = m.inspect(func(r io.Reader) (err error) {
+		head, err = readHead(r, 512)
+		return err
+	}); err != nil {
+		return &MediaError{Media: m.String(), Message: "failed to read", Err: err}
+	}
+
+	if m.size == 0 || len(head) == 0 {
+		return &MediaError{Media: m.String(), Message: "media is empty", Err: ErrMediaEmpty}
+	}
+	if m.size > 0 && limit.maxSize > 0 && m.size > limit.maxSize {
+		return &MediaError{Media: m.String(), Message: fmt.Sprintf("%s is larger than %d bytes", kind, limit.maxSize), Err: ErrMediaTooLarge}
+	}
+	if m.size > 0 && m.size < limit.minSize {
+		return &MediaError{Media: m.String(), Message: fmt.Sprintf("%s is smaller than %d bytes", kind, limit.minSize), Err: ErrMediaTooSmall}
+	}
+
+	m.format = detectFormat(head)
+	if !containsString(limit.formats, m.format) {
+		if m.format == "" {
+			return &MediaError{Media: m.String(), Message: fmt.Sprintf("unknown %s format (supported: %s)", kind, strings.Join(limit.formats, ", ")), Err: ErrMediaFormat}
+		}
+		return &MediaError{Media: m.String(), Message: fmt.Sprintf("%s format %s is not supported (supported: %s)", kind, m.format, strings.Join(limit.formats, ", ")), Err: ErrMediaFormat}
+	}
+
+	if kind == MediaImage && limit.hasDimensions() {
+		var config image.Config
+		if err = m.inspect(func(r io.Reader) (err error) {
+			config, err = decodeImageConfig(r, m.format)
+			return err
+		}); err != nil {
+			return &MediaError{Media: m.String(), Message: fmt.Sprintf("failed to decode %s header: %s", m.format, err), Err: ErrMediaUndecodable}
+		}
+		m.width, m.height = config.Width, config.Height
+
+		if m.width < limit.minWidth || m.height < limit.minHeight {
+			return &MediaError{Media: m.String(), Message: fmt.Sprintf("image is %dx%d pixels, smaller than %dx%d", m.width, m.height, limit.minWidth, limit.minHeight), Err: ErrMediaDimensions}
+		}
+		if (limit.maxWidth > 0 && m.width > limit.maxWidth) || (limit.maxHeight > 0 && m.height > limit.maxHeight) {
+			return &MediaError{Media: m.String(), Message: fmt.Sprintf("image is %dx%d pixels, larger than %dx%d", m.width, m.height, limit.maxWidth, limit.maxHeight), Err: ErrMediaDimensions}
+		}
+	}
+
+	return nil
+}
+
+// run fn with a reader of the media from its beginning, without consuming it
+func (m *Media) inspect(fn func(r io.Reader) error) (err error) {
+	switch {
+	case m.data != nil:
+		return fn(bytes.NewReader(m.data))
+	case m.path != "":
+		var file *os.File
+		if file, err = os.Open(m.path); err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if m.size < 0 {
+			m.size = readerSize(file)
+		}
+		return fn(file)
+	case m.reader != nil:
+		if seeker, ok := m.reader.(io.ReadSeeker); ok {
+			var offset int64
+			if offset, err = seeker.Seek(0, io.SeekCurrent); err == nil {
+				if err = fn(seeker); err == nil {
+					_, err = seeker.Seek(offset, io.SeekStart)
+				}
+			}
+			return err
+		}
+
+		// put the consumed part back in front of the rest
+		var consumed bytes.Buffer
+		err = fn(io.TeeReader(m.reader, &consumed))
+		m.reader = io.MultiReader(bytes.NewReader(consumed.Bytes()), m.reader)
+		return err
+	}
+	return fn(bytes.NewReader(nil))
+}
+
+// read up to n bytes from given reader
+func readHead(reader io.Reader, n int) (head []byte, err error) {
+	head = make([]byte, n)
+	if n, err = io.ReadFull(reader, head); err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return head[:n], err
+}
+
+// check if given string is in the slice
+func containsString(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
+
+// decode the dimensions of an image in given format
+func decodeImageConfig(r io.Reader, format string) (config image.Config, err error) {
+	if format == FormatBMP {
+		return decodeBmpConfig(r)
+	}
+	config, _, err = image.DecodeConfig(r)
+	return config, err
+}
+
+// decode the dimensions of a bmp image from its file and info headers
+func decodeBmpConfig(r io.Reader) (config image.Config, err error) {
+	header := make([]byte, 26)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return config, err
+	}
+
+	if infoSize := binary.LittleEndian.Uint32(header[14:18]); infoSize == 12 { // BITMAPCOREHEADER
+		config.Width = int(binary.LittleEndian.Uint16(header[18:20]))
+		config.Height = int(binary.LittleEndian.Uint16(header[20:22]))
+	} else {
+		config.Width = int(int32(binary.LittleEndian.Uint32(header[18:22])))
+		config.Height = int(int32(binary.LittleEndian.Uint32(header[22:26])))
+		if config.Height < 0 { // top-down bitmap
+			config.Height = -config.Height
+		}
+	}
+	if config.Width <= 0 || config.Height == 0 {
+		return config, fmt.Errorf("invalid bmp dimensions: %dx%d", config.Width, config.Height)
+	}
+	return config, nil
+}
+
+// detect the format of media from its header
+func detectFormat(head []byte) string {
+	switch {
+	case bytes.HasPrefix(head, []byte{0xFF, 0xD8, 0xFF}):
+		return FormatJPEG
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return FormatGIF
+	case bytes.HasPrefix(head, []byte("BM")):
+		return FormatBMP
+	case len(head) >= 12 && string(head[4:8]) == "ftyp":
+		if string(head[8:12]) == "qt  " {
+			return FormatMOV
+		}
+		return FormatMP4
+	case len(head) >= 8 && string(head[4:8]) == "moov", len(head) >= 8 && string(head[4:8]) == "mdat":
+		return FormatMOV
+	case bytes.HasPrefix(head, []byte{0x30, 0x26, 0xB2, 0x75, 0x8E, 0x66, 0xCF, 0x11}): // ASF
+		return FormatWMV
+	}
+	return ""
+}
+
+// post the media to given url
+func (c *Client) postMedia(ctx context.Context, url, key string, params map[string]string, media Media) (result []byte, err error) {
+	switch {
+	case media.url != "":
+		return c.httpPost(
+			ctx,
+			url,
+			key,
+			params,
+			struct {
+				Url string `json:"url"`
+			}{Url: media.url},
+		)
+	case media.data != nil:
+		return c.httpPostBytes(ctx, url, key, params, media.data)
+	case media.path != "":
+		return c.httpPostFile(ctx, url, key, params, File(media.path))
+	}
+	return c.httpPostReader(ctx, url, key, params, media.reader, media.size)
+}
@@ -0,0 +1,164 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestDetectFormat(t *testing.T) {
+	for head, expected := range map[string]string{
+		"\xff\xd8\xff\xe0\x00\x10JFIF":                     FormatJPEG,
+		"\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR":            FormatPNG,
+		"GIF89a\x01\x00\x01\x00":                           FormatGIF,
+		"BM\x36\x00\x00\x00":                               FormatBMP,
+		"\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00":         FormatMP4,
+		"\x00\x00\x00\x14ftypqt  \x00\x00\x00\x00":         FormatMOV,
+		"\x30\x26\xb2\x75\x8e\x66\xcf\x11\xa6\xd9\x00\xaa": FormatWMV,
+		"<html></html>":                                    "",
+	} {
+		if format := detectFormat([]byte(head)); format != expected {
+			t.Errorf("detectFormat(%q) = %s, expected: %s", head, format, expected)
+		}
+	}
+}
+
+// encode a noisy png image of given dimensions
+func testPng(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	rand.New(rand.NewSource(int64(width * height))).Read(img.Pix)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Failed to encode png: %s", err)
+	}
+	return buf.Bytes()
+}
+
+func TestMediaValidate(t *testing.T) {
+	png := testPng(t, 100, 100)
+	faceLimit := mediaLimitOf("https://westus.api.cognitive.microsoft.com/face/v1.0/detect", MediaImage)
+
+	// valid media
+	for _, media := range []Media{
+		FromURL("https://some.where/image.jpg"),
+		FromBytes(png),
+		FromReader(bytes.NewReader(png), int64(len(png))),
+		FromReader(plainReader{bytes.NewReader(png)}, -1),
+	} {
+		if err := media.validate(faceLimit, MediaImage); err != nil {
+			t.Errorf("validate(%s) failed: %s", media, err)
+		}
+	}
+
+	// invalid media
+	for _, media := range []Media{
+		FromURL("ftp://some.where/image.jpg"),
+		FromURL("not a url"),
+		FromBytes([]byte{}),
+		FromBytes([]byte("\x89PNG\r\n\x1a\n")),                                // smaller than 1KB
+		FromBytes(make([]byte, 5*1024*1024)),                                  // larger than 4MB
+		FromBytes(append([]byte("<html>"), make([]byte, 2048)...)),            // unknown format
+		FromBytes(append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 2048)...)), // undecodable header
+		FromFile("/not/existing/file.jpg"),
+	} {
+		var mediaErr *MediaError
+		if err := media.validate(faceLimit, MediaImage); !errors.As(err, &mediaErr) {
+			t.Errorf("validate(%s) should fail with MediaError, got: %v", media, err)
+		}
+	}
+
+	// image is not a valid video
+	media := FromBytes(png)
+	if err := media.validate(mediaLimitOf("/video/v1.0/stabilize", MediaVideo), MediaVideo); err == nil {
+		t.Errorf("PNG image should not be accepted as a video")
+	}
+
+	// header of non-seekable stream is not consumed
+	media = FromReader(plainReader{bytes.NewReader(png)}, -1)
+	if err := media.validate(mediaLimitOf("/vision/v1.0/analyze", MediaImage), MediaImage); err != nil || media.Format() != FormatPNG {
+		t.Errorf("validate() = %v, format: %s", err, media.Format())
+	}
+	if width, height := media.Dimensions(); width != 100 || height != 100 {
+		t.Errorf("Unexpected dimensions: %dx%d", width, height)
+	}
+	if read, _ := io.ReadAll(media.reader); !bytes.Equal(read, png) {
+		t.Errorf("Stream was consumed while validating: %d bytes left", len(read))
+	}
+}
+
+func TestMediaDimensions(t *testing.T) {
+	small, large := testPng(t, 30, 30), testPng(t, 3500, 40)
+
+	for _, test := range []struct {
+		apiUrl string
+		image  []byte
+		valid  bool
+	}{
+		{"/face/v1.0/detect", small, false},
+		{"/face/v1.0/detect", large, true},
+		{"/emotion/v1.0/recognize", small, false},
+		{"/vision/v1.0/analyze", large, false}, // 40 < 50
+		{"/vision/v1.0/ocr", large, false},     // 3500 > 3200
+		{"/vision/v1.0/tag", small, true},      // dimensions are not checked
+	} {
+		media := FromBytes(test.image)
+		err := media.validate(mediaLimitOf(test.apiUrl, MediaImage), MediaImage)
+		if test.valid && err != nil {
+			t.Errorf("validate(%s) failed: %s", test.apiUrl, err)
+		} else if !test.valid && !errors.Is(err, ErrMediaDimensions) {
+			t.Errorf("validate(%s) should fail with ErrMediaDimensions, got: %v", test.apiUrl, err)
+		}
+	}
+
+	// bmp headers
+	bmp := append([]byte("BM"), make([]byte, 24)...)
+	binary.LittleEndian.PutUint32(bmp[14:], 40)
+	binary.LittleEndian.PutUint32(bmp[18:], 640)
+	binary.LittleEndian.PutUint32(bmp[22:], uint32(0xFFFFFFFF-480+1)) // -480: top-down
+	if config, err := decodeImageConfig(bytes.NewReader(bmp), FormatBMP); err != nil || config.Width != 640 || config.Height != 480 {
+		t.Errorf("decodeImageConfig(bmp) = %+v, %v", config, err)
+	}
+}
+
+func TestMediaBeforeRequest(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		io.WriteString(w, `[]`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+
+	// unsupported type, url, and format
+	for _, image := range []interface{}{
+		123,
+		"file:///etc/passwd",
+		strings.NewReader("not an image"),
+	} {
+		var mediaErr *MediaError
+		if _, err := client.FaceDetect(context.Background(), WestUS, "test-key", image, true, false, nil); !errors.As(err, &mediaErr) {
+			t.Errorf("FaceDetect(%T) should fail with MediaError, got: %v", image, err)
+		}
+	}
+	if requests != 0 {
+		t.Errorf("Requests should not be sent with invalid media: %d requests", requests)
+	}
+
+	// valid media
+	if _, err := client.FaceDetect(context.Background(), WestUS, "test-key", FromBytes(testPng(t, 100, 100)), true, false, nil); err != nil {
+		t.Errorf("FaceDetect() failed: %s", err)
+	}
+}
@@ -0,0 +1,101 @@
+package cognitive
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// function which sends a request and returns its response
+type RoundTripFunc func(req *http.Request) (*http.Response, error)
+
+// middleware which wraps a RoundTripFunc, for inspecting or mutating requests and responses
+//
+// can be added to Client.Middlewares, or composed with Chain()
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// compose given middlewares in order (the first one is the outermost)
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// metrics of an attempt of a request
+type RequestMetrics struct {
+	Method     string        // http method of the request
+	Url        string        // url of the request
+	Service    Service       // service of the request
+	Attempt    int           // number of the attempt (starting from 1)
+	StatusCode int           // http status code (0 if no response was received)
+	Err        error         // error of the attempt (nil if a response was received)
+	Duration   time.Duration // duration until the response (or error) was received
+}
+
+// middleware which calls record with the metrics of each attempt
+//
+// should be placed inside RetryMiddleware() for recording every attempt
+func MetricsMiddleware(record func(metrics RequestMetrics)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			started := time.Now()
+			resp, err := next(req)
+
+			metrics := RequestMetrics{
+				Method:   req.Method,
+				Url:      req.URL.String(),
+				Service:  serviceOf(req.URL),
+				Attempt:  attemptOf(req.Context()),
+				Err:      err,
+				Duration: time.Since(started),
+			}
+			if resp != nil {
+				metrics.StatusCode = resp.StatusCode
+			}
+			record(metrics)
+
+			return resp, err
+		}
+	}
+}
+
+// key of attempt number in request contexts
+type attemptKey struct{}
+
+// get the attempt number of a request from its context (1 if not retried)
+func attemptOf(ctx context.Context) int {
+	if attempt, ok := ctx.Value(attemptKey{}).(int); ok {
+		return attempt
+	}
+	return 1
+}
+
+// chain of the client's instrumentation, middlewares, retry policy, rate limiters, usage recorder, and logger, which sends requests at last
+//
+// (instrumentation => client's middlewares => retry => rate limit => usage => logging => http client)
+func (c *Client) roundTrip() RoundTripFunc {
+	next := RoundTripFunc(c.httpClient().Do)
+	if c.Instrumentation != nil {
+		next = countAttemptsMiddleware(next)
+	}
+	if logger := c.logger(); logger != nil {
+		next = LoggingMiddleware(logger, c.LogOptions)(next)
+	}
+	if c.Usage != nil {
+		next = UsageMiddleware(c.Usage)(next)
+	}
+	next = c.rateLimitMiddleware()(next)
+	if c.Retry != nil {
+		next = retryMiddleware(c.Retry, func(req *http.Request, event RetryEvent) {
+			c.logRetry(req.Context(), event)
+		})(next)
+	}
+	next = Chain(c.Middlewares...)(next)
+	if c.Instrumentation != nil {
+		next = instrumentationMiddleware(c.Instrumentation)(next)
+	}
+	return next
+}
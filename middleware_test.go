@@ -0,0 +1,103 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMiddlewares(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h := r.Header.Get("X-Gateway"); h != "gateway-1" {
+			t.Errorf("Unexpected X-Gateway header: %s", h)
+		}
+		if h := r.Header.Get("X-Trace"); h != "outer,inner" {
+			t.Errorf("Middlewares were not applied in order: %s", h)
+		}
+
+		if atomic.AddInt32(&requests, 1) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `[]`)
+	}))
+	defer server.Close()
+
+	// a middleware which appends its name to X-Trace header
+	trace := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				trace := name
+				if h := req.Header.Get("X-Trace"); h != "" {
+					trace = strings.SplitN(h, ",", 2)[0] + "," + name
+				}
+				req.Header.Set("X-Trace", trace)
+				return next(req)
+			}
+		}
+	}
+
+	metrics := []RequestMetrics{}
+	client := &Client{
+		BaseUrl: server.URL,
+		Headers: map[string]string{"X-Gateway": "gateway-1"},
+		Middlewares: []Middleware{
+			trace("outer"),
+			Chain(
+				RetryMiddleware(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}),
+				trace("inner"),
+				MetricsMiddleware(func(m RequestMetrics) {
+					metrics = append(metrics, m)
+				}),
+			),
+		},
+	}
+
+	if _, err := client.FaceGetLists(context.Background(), WestUS, "test-key"); err != nil {
+		t.Errorf("FaceGetLists() failed: %s", err)
+	}
+
+	if requests != 2 || len(metrics) != 2 {
+		t.Fatalf("Unexpected requests: %d, metrics: %+v", requests, metrics)
+	}
+	if m := metrics[0]; m.Attempt != 1 || m.StatusCode != http.StatusServiceUnavailable || m.Service != ServiceFace || m.Method != "GET" {
+		t.Errorf("Unexpected metrics of the first attempt: %+v", m)
+	}
+	if m := metrics[1]; m.Attempt != 2 || m.StatusCode != http.StatusOK || m.Duration <= 0 {
+		t.Errorf("Unexpected metrics of the second attempt: %+v", m)
+	}
+}
+
+func TestMiddlewareShortCircuitWithLogger(t *testing.T) {
+	// a middleware which returns its own response (without Request), eg. a cache
+	cache := func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader(`[{"faceListId":"cached"}]`)),
+			}, nil
+		}
+	}
+
+	var buf bytes.Buffer
+	client := &Client{
+		Logger:      slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		Middlewares: []Middleware{cache},
+	}
+
+	if lists, err := client.FaceGetLists(context.Background(), WestUS, "test-key"); err != nil || len(lists) != 1 || lists[0].FaceListId != "cached" {
+		t.Errorf("FaceGetLists() = %+v, %v", lists, err)
+	}
+	if logs := buf.String(); !strings.Contains(logs, "msg=\"response body\"") {
+		t.Errorf("Response body should be logged:\n%s", logs)
+	}
+}
@@ -0,0 +1,462 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// options for polling long-running operations
+type PollOptions struct {
+	InitialDelay time.Duration // delay before the first poll (default: 1 second)
+	Interval     time.Duration // delay between polls (default: WaitSeconds)
+	Backoff      float64       // multiplier of the delay after each poll (default: 1.0 = fixed interval)
+	MaxInterval  time.Duration // maximum delay between polls (default: no limit)
+	MaxTries     int           // maximum number of polls (default: NumTries)
+	Timeout      time.Duration // maximum duration of Wait() (default: no limit)
+}
+
+// default values of PollOptions
+const (
+	DefaultPollInitialDelay = 1 * time.Second
+)
+
+// delay before given poll (starting from 0)
+func (o PollOptions) delay(poll int) time.Duration {
+	if poll == 0 {
+		if o.InitialDelay > 0 {
+			return o.InitialDelay
+		}
+		return DefaultPollInitialDelay
+	}
+
+	interval := o.Interval
+	if interval <= 0 {
+		interval = WaitSeconds * time.Second
+	}
+	if o.Backoff > 1.0 {
+		for i := 1; i < poll; i++ {
+			interval = time.Duration(float64(interval) * o.Backoff)
+			if o.MaxInterval > 0 && interval >= o.MaxInterval {
+				break
+			}
+		}
+	}
+	if o.MaxInterval > 0 && interval > o.MaxInterval {
+		interval = o.MaxInterval
+	}
+	return interval
+}
+
+// maximum number of polls
+func (o PollOptions) maxTries() int {
+	if o.MaxTries > 0 {
+		return o.MaxTries
+	}
+	return NumTries
+}
+
+// error returned when a long-running operation failed
+type OperationError struct {
+	Location string // url of the operation
+	Message  string // error message from the service
+}
+
+func (e *OperationError) Error() string {
+	if e.Message == "" {
+		return "operation failed"
+	}
+	return "operation failed; " + e.Message
+}
+
+// kinds of long-running operations
+type OperationKind string
+
+const (
+	OperationVideoFaceDetectTrack               OperationKind = "video/trackface"
+	OperationVideoMotionDetect                  OperationKind = "video/detectmotion"
+	OperationVideoStabilize                     OperationKind = "video/stabilize"
+	OperationVideoThumbnail                     OperationKind = "video/generatethumbnail"
+	OperationEmotionRecognizeVideo              OperationKind = "emotion/recognizeinvideo"
+	OperationComputerVisionRecognizeHandwritten OperationKind = "vision/recognizeText"
+)
+
+// service of the operation kind (eg. OperationVideoStabilize => ServiceVideo)
+func (k OperationKind) Service() Service {
+	return Service(strings.SplitN(string(k), "/", 2)[0])
+}
+
+// path segment of the operation kind's locations (eg. "operations" in "https://westus.api.cognitive.microsoft.com/video/v1.0/operations/OPERATION-ID")
+func (k OperationKind) operationsPath() string {
+	if k == OperationComputerVisionRecognizeHandwritten {
+		return "textOperations"
+	}
+	return "operations"
+}
+
+// check if the operation kind is known
+func (k OperationKind) valid() bool {
+	switch k {
+	case OperationVideoFaceDetectTrack,
+		OperationVideoMotionDetect,
+		OperationVideoStabilize,
+		OperationVideoThumbnail,
+		OperationEmotionRecognizeVideo,
+		OperationComputerVisionRecognizeHandwritten:
+		return true
+	}
+	return false
+}
+
+// serializable handle of a long-running operation
+//
+// can be stored (eg. in json format) and resumed later with ResumeOperation().
+// (subscription key is not included, so it should be given again when resuming)
+type OperationHandle struct {
+	Kind     OperationKind `json:"kind"`
+	Location string        `json:"location"`        // url of the operation (from Operation-Location header)
+	Scale    []float64     `json:"scale,omitempty"` // scale factors (x, y) of the image downscaled before uploading (nil if not downscaled)
+}
+
+// long-running operation (eg. video processing, handwriting recognition)
+//
+// returned from functions like VideoStabilizeSubmit(), and can be polled until it is done.
+//
+// marshalled to json as its OperationHandle.
+type Operation struct {
+	Kind             OperationKind                         // kind of the operation
+	Location         string                                // url of the operation (from Operation-Location header)
+	Options          PollOptions                           // options for Wait()
+	ProgressNotifier func(status string, progress float32) // called when the progress changes (can be nil)
+
+	client       *Client
+	key          string
+	fallbackKeys []string        // keys for polling in order, when key fails with 401, 403, or 429
+	transform    *imageTransform // for mapping coordinates in the result back to the original image
+
+	lock              sync.RWMutex
+	polled            bool
+	status            OperationStatus
+	recognitionResult json.RawMessage
+}
+
+// create a new operation for given location
+func (c *Client) newOperation(kind OperationKind, location, key string) *Operation {
+	op := &Operation{
+		Kind:     kind,
+		Location: location,
+		client:   c,
+		key:      key,
+	}
+	if c.PollOptions != nil {
+		op.Options = *c.PollOptions
+	}
+	return op
+}
+
+// create a new operation for the location returned from a submitted request
+func (c *Client) submittedOperation(kind OperationKind, location []byte, key string) (op *Operation, err error) {
+	if len(location) == 0 {
+		return nil, fmt.Errorf("Operation-Location header is empty")
+	}
+	if u, err := url.Parse(string(location)); err != nil || !u.IsAbs() {
+		return nil, fmt.Errorf("Invalid operation location: %s", string(location))
+	}
+	return c.newOperation(kind, string(location), key), nil
+}
+
+// reattach to an existing long-running operation with its handle
+//
+// location : API location where the operation was submitted
+// key      : subscription key for the operation's API
+func ResumeOperation(
+	location ApiLocation,
+	key string,
+	handle OperationHandle,
+) (op *Operation, err error) {
+	return DefaultClient.ResumeOperation(location, key, handle)
+}
+
+// reattach to an existing long-running operation with its handle, through this client
+//
+// handle.Location should be an https url of the operation under given location (or under Endpoint or BaseUrl of the client, where only BaseUrl can be http),
+// so that the key is not sent to any other host
+//
+// location : API location where the operation was submitted
+// key      : subscription key for the operation's API
+func (c *Client) ResumeOperation(
+	location ApiLocation,
+	key string,
+	handle OperationHandle,
+) (op *Operation, err error) {
+	if !handle.Kind.valid() {
+		return nil, fmt.Errorf("Unknown operation kind: %s", handle.Kind)
+	}
+	if err = c.validateOperationLocation(location, handle.Kind, handle.Location); err != nil {
+		return nil, err
+	}
+
+	op = c.newOperation(handle.Kind, handle.Location, key)
+	op.transform = newImageTransform(handle.Scale)
+
+	return op, nil
+}
+
+// reattach to an existing long-running operation with its handle, using the keys of given provider
+//
+// keys of the resource where the operation was submitted (whose locations match handle.Location) are used for polling,
+// in order of provider's keys, failing over to the next one on 401, 403, and 429
+func ResumeOperationWithKeys(
+	provider KeyProvider,
+	handle OperationHandle,
+) (op *Operation, err error) {
+	return DefaultClient.ResumeOperationWithKeys(provider, handle)
+}
+
+// reattach to an existing long-running operation with its handle, using the keys of given provider, through this client
+//
+// keys of the resource where the operation was submitted (whose locations match handle.Location) are used for polling,
+// in order of provider's keys, failing over to the next one on 401, 403, and 429
+func (c *Client) ResumeOperationWithKeys(
+	provider KeyProvider,
+	handle OperationHandle,
+) (op *Operation, err error) {
+	keys := keysOf(provider)
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	for _, k := range keys {
+		if op == nil {
+			if op, err = c.ResumeOperation(k.Location, k.Key, handle); err != nil {
+				op = nil
+			}
+		} else if c.validateOperationLocation(k.Location, handle.Kind, handle.Location) == nil {
+			op.fallbackKeys = append(op.fallbackKeys, k.Key)
+		}
+	}
+	if op == nil {
+		return nil, fmt.Errorf("No subscription key for the operation (%s): %s", handle.Location, err)
+	}
+	return op, nil
+}
+
+// check if the location of an operation is under the API url of given location (or BaseUrl, Endpoint), with the path of its kind
+//
+// (eg. "https://westus.api.cognitive.microsoft.com/video/v1.0/operations/OPERATION-ID" for OperationVideoStabilize)
+func (c *Client) validateOperationLocation(apiLocation ApiLocation, kind OperationKind, location string) error {
+	u, err := url.Parse(location)
+	if err != nil || !u.IsAbs() || u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("Invalid operation location: %s", location)
+	}
+
+	base, err := url.Parse(c.apiUrl(apiLocation, ""))
+	if err != nil {
+		return fmt.Errorf("Invalid endpoint: %s", err)
+	}
+	if u.Scheme != "https" && (c.BaseUrl == "" || u.Scheme != "http") {
+		return fmt.Errorf("Operation location should be an https url: %s", location)
+	}
+	if u.Scheme != base.Scheme || !strings.EqualFold(u.Host, base.Host) {
+		return fmt.Errorf("Operation location is not on the host of the endpoint (%s): %s", base.Host, location)
+	}
+
+	prefix := strings.TrimRight(base.Path, "/") + "/" + string(kind.Service()) + "/" + apiVersion + "/" + kind.operationsPath() + "/"
+	if id := strings.TrimPrefix(u.Path, prefix); id == u.Path || id == "" || strings.Contains(id, "/") {
+		return fmt.Errorf("Operation location does not match %s*: %s", prefix, location)
+	}
+	return nil
+}
+
+// handle of the operation, which can be stored and resumed later
+func (o *Operation) Handle() OperationHandle {
+	return OperationHandle{
+		Kind:     o.Kind,
+		Location: o.Location,
+		Scale:    o.transform.scale(),
+	}
+}
+
+// marshal the operation as its handle
+func (o *Operation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.Handle())
+}
+
+// poll the status of the operation once
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e36
+//
+// returns true if the operation is done (succeeded or failed), and *OperationError if it failed
+func (o *Operation) Poll(ctx context.Context) (done bool, err error) {
+	o.client.log(ctx, slog.LevelDebug, "polling operation", "kind", o.Kind, "location", o.Location)
+
+	var result []byte
+	if result, err = o.get(withPoll(withOperationName(ctx, operationPollNames[o.Kind]))); err != nil {
+		return false, err
+	}
+
+	var status struct {
+		OperationStatus
+		RecognitionResult json.RawMessage `json:"recognitionResult"`
+	}
+	if err = json.Unmarshal(result, &status); err != nil {
+		return false, err
+	}
+
+	o.lock.Lock()
+	changed := !o.polled || o.status.Status != status.Status || o.status.Progress != status.Progress
+	o.polled = true
+	o.status = status.OperationStatus
+	o.recognitionResult = status.RecognitionResult
+	o.lock.Unlock()
+
+	switch processingStatusOf(status.Status) {
+	case StatusSucceeded:
+		return true, nil
+	case StatusFailed:
+		return true, &OperationError{Location: o.Location, Message: status.Message}
+	}
+
+	if changed {
+		if o.ProgressNotifier != nil {
+			o.ProgressNotifier(status.Status, status.Progress)
+		}
+
+		o.client.log(ctx, slog.LevelDebug, "operation progress", "kind", o.Kind, "status", status.Status, "progress", status.Progress)
+	}
+
+	return false, nil
+}
+
+// get the status of the operation, failing over to the fallback keys
+func (o *Operation) get(ctx context.Context) (result []byte, err error) {
+	o.lock.RLock()
+	key, fallbackKeys := o.key, o.fallbackKeys
+	o.lock.RUnlock()
+
+	for {
+		if result, err = o.client.httpGet(ctx, o.Location, key, nil); err == nil || !shouldFailover(err) || len(fallbackKeys) == 0 || ctx.Err() != nil {
+			break
+		}
+		key, fallbackKeys = fallbackKeys[0], fallbackKeys[1:]
+	}
+
+	// keep using the key which worked
+	if err == nil {
+		o.lock.Lock()
+		o.key, o.fallbackKeys = key, fallbackKeys
+		o.lock.Unlock()
+	}
+	return result, err
+}
+
+// poll the operation until it is done, ctx is done, or the limit of Options is reached
+//
+// returns immediately when polling fails with an error which is not transient (eg. 401, 404, or a malformed response),
+// and keeps polling after transient ones (eg. 429, 5xx, or timeouts)
+func (o *Operation) Wait(ctx context.Context) (err error) {
+	if o.Options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Options.Timeout)
+		defer cancel()
+	}
+
+	maxTries := o.Options.maxTries()
+	for i := 0; i < maxTries; i++ {
+		if err = sleep(ctx, o.Options.delay(i)); err != nil {
+			return err
+		}
+
+		var done bool
+		if done, err = o.Poll(ctx); done || ctx.Err() != nil || (err != nil && !isTransientError(err)) {
+			return err
+		}
+	}
+
+	if err == nil {
+		err = fmt.Errorf("Reached the limit of tries: %d", maxTries)
+	}
+	return err
+}
+
+// status of the operation from the last poll (StatusNotStarted, StatusUploading, StatusRunning, StatusSucceeded, or StatusFailed)
+//
+// empty if not polled yet
+func (o *Operation) Status() ProcessingStatus {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return processingStatusOf(o.status.Status)
+}
+
+// progress of the operation from the last poll (0.0 ~ 100.0)
+func (o *Operation) Progress() float32 {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	return o.status.Progress
+}
+
+// check if the operation is done (succeeded or failed)
+func (o *Operation) Done() bool {
+	return o.Status().IsDone()
+}
+
+// decode the result of a succeeded operation into v
+//
+// v should be:
+//
+// *string for operations which generate files (eg. VideoStabilize, VideoThumbnail),
+//
+// a pointer to the result type for others (eg. *VideoProcessingResult2, *ComputerVisionHandwrittenProcessingResult).
+func (o *Operation) Result(v interface{}) error {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+
+	switch processingStatusOf(o.status.Status) {
+	case StatusSucceeded: // ok
+	case StatusFailed:
+		return &OperationError{Location: o.Location, Message: o.status.Message}
+	default:
+		return fmt.Errorf("Operation is not succeeded yet: %s", o.status.Status)
+	}
+
+	if location, ok := v.(*string); ok {
+		if o.status.ResourceLocation == "" {
+			return fmt.Errorf("resourceLocation is empty")
+		}
+		*location = o.status.ResourceLocation
+		return nil
+	}
+
+	var err error
+	if len(o.recognitionResult) > 0 {
+		err = json.Unmarshal(o.recognitionResult, v)
+	} else if o.status.ProcessingResultJson == "" {
+		return fmt.Errorf("processingResult is empty")
+	} else {
+		err = json.Unmarshal([]byte(o.status.ProcessingResultJson), v)
+	}
+
+	if mapper, ok := v.(coordinatesMapper); ok && err == nil {
+		mapper.mapCoordinates(o.transform)
+	}
+	return err
+}
+
+// wait for the operation and decode its result into v
+func (o *Operation) waitResult(ctx context.Context, progressNotifier func(status string, progress float32), v interface{}) (err error) {
+	markSubmitted(ctx)
+
+	o.ProgressNotifier = progressNotifier
+
+	if err = o.Wait(ctx); err == nil {
+		err = o.Result(v)
+	}
+	return err
+}
@@ -0,0 +1,316 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fast polling for tests
+var testPollOptions = &PollOptions{
+	InitialDelay: time.Millisecond,
+	Interval:     time.Millisecond,
+}
+
+func TestPollOptionsDelay(t *testing.T) {
+	options := PollOptions{
+		Interval:    100 * time.Millisecond,
+		Backoff:     2.0,
+		MaxInterval: 300 * time.Millisecond,
+	}
+
+	for poll, expected := range map[int]time.Duration{
+		0: DefaultPollInitialDelay,
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		3: 300 * time.Millisecond,
+		9: 300 * time.Millisecond,
+	} {
+		if delay := options.delay(poll); delay != expected {
+			t.Errorf("delay(%d) = %s, expected: %s", poll, delay, expected)
+		}
+	}
+
+	// defaults
+	if delay := (PollOptions{}).delay(1); delay != WaitSeconds*time.Second {
+		t.Errorf("Unexpected default interval: %s", delay)
+	}
+	if tries := (PollOptions{}).maxTries(); tries != NumTries {
+		t.Errorf("Unexpected default max tries: %d", tries)
+	}
+}
+
+func TestOperationWait(t *testing.T) {
+	var polls int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/video/v1.0/stabilize":
+			w.Header().Set("Operation-Location", server.URL+"/video/v1.0/operations/1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/video/v1.0/operations/1":
+			if atomic.AddInt32(&polls, 1) < 3 {
+				io.WriteString(w, `{"status":"Running","progress":50.0}`)
+			} else {
+				io.WriteString(w, `{"status":"Succeeded","progress":100.0,"resourceLocation":"http://localhost/result.mp4"}`)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL, PollOptions: testPollOptions}
+
+	op, err := client.VideoStabilizeSubmit(context.Background(), "test-key", "http://localhost/video.mp4")
+	if err != nil {
+		t.Fatalf("VideoStabilizeSubmit() failed: %s", err)
+	}
+	if op.Location != server.URL+"/video/v1.0/operations/1" || op.Done() {
+		t.Errorf("Unexpected operation: %s, %s", op.Location, op.Status())
+	}
+
+	notified := 0
+	op.ProgressNotifier = func(status string, progress float32) {
+		notified++
+	}
+	if err = op.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() failed: %s", err)
+	}
+	if !op.Done() || op.Status() != StatusSucceeded || op.Progress() != 100.0 || notified != 1 {
+		t.Errorf("Unexpected operation: %s (%.2f%%), notified %d times", op.Status(), op.Progress(), notified)
+	}
+
+	var fileUrl string
+	if err = op.Result(&fileUrl); err != nil || fileUrl != "http://localhost/result.mp4" {
+		t.Errorf("Result() = %s, %v", fileUrl, err)
+	}
+
+	// blocking call with the same operation
+	atomic.StoreInt32(&polls, 0)
+	if fileUrl, err = client.VideoStabilize(context.Background(), "test-key", "http://localhost/video.mp4", nil); err != nil || fileUrl != "http://localhost/result.mp4" {
+		t.Errorf("VideoStabilize() = %s, %v", fileUrl, err)
+	}
+}
+
+func TestOperationResult(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/processing":
+			io.WriteString(w, `{"status":"Succeeded","processingResult":"{\"version\":1,\"timescale\":30}"}`)
+		case "/recognition":
+			io.WriteString(w, `{"status":"Succeeded","recognitionResult":{"lines":[{"text":"hello"}]}}`)
+		case "/failed":
+			io.WriteString(w, `{"status":"Failed","message":"Unsupported video format"}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{PollOptions: testPollOptions}
+
+	// processingResult
+	op := client.newOperation(OperationVideoMotionDetect, server.URL+"/processing", "test-key")
+	if err := op.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() failed: %s", err)
+	}
+	var videoResult VideoProcessingResult2
+	if err := op.Result(&videoResult); err != nil || videoResult.Version != 1 || videoResult.Timescale != 30 {
+		t.Errorf("Result() = %+v, %v", videoResult, err)
+	}
+
+	// recognitionResult
+	op = client.newOperation(OperationComputerVisionRecognizeHandwritten, server.URL+"/recognition", "test-key")
+	if err := op.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() failed: %s", err)
+	}
+	var handwritten ComputerVisionHandwrittenProcessingResult
+	if err := op.Result(&handwritten); err != nil || len(handwritten.Lines) != 1 || handwritten.Lines[0].Text != "hello" {
+		t.Errorf("Result() = %+v, %v", handwritten, err)
+	}
+
+	// failed
+	op = client.newOperation(OperationVideoMotionDetect, server.URL+"/failed", "test-key")
+	var opErr *OperationError
+	if err := op.Wait(context.Background()); !errors.As(err, &opErr) || opErr.Message != "Unsupported video format" {
+		t.Errorf("Wait() should fail with OperationError, got: %v", err)
+	}
+	if err := op.Result(&videoResult); !errors.As(err, &opErr) {
+		t.Errorf("Result() should fail with OperationError, got: %v", err)
+	}
+}
+
+func TestOperationLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"status":"Running","progress":10.0}`)
+	}))
+	defer server.Close()
+
+	client := &Client{}
+
+	// max tries
+	op := client.newOperation(OperationVideoStabilize, server.URL, "test-key")
+	op.Options = PollOptions{InitialDelay: time.Millisecond, Interval: time.Millisecond, MaxTries: 3}
+	if err := op.Wait(context.Background()); err == nil {
+		t.Errorf("Wait() should fail after max tries")
+	}
+	if err := op.Result(new(string)); err == nil {
+		t.Errorf("Result() should fail while running")
+	}
+
+	// timeout
+	op = client.newOperation(OperationVideoStabilize, server.URL, "test-key")
+	op.Options = PollOptions{InitialDelay: time.Millisecond, Interval: 10 * time.Millisecond, Timeout: 30 * time.Millisecond}
+	if err := op.Wait(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() should time out, got: %v", err)
+	}
+
+	// cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.newOperation(OperationVideoStabilize, server.URL, "test-key").Wait(ctx); err != context.Canceled {
+		t.Errorf("Wait() should be cancelled, got: %v", err)
+	}
+}
+
+func TestOperationPollErrors(t *testing.T) {
+	var polls, statusCode int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.WriteHeader(int(atomic.LoadInt32(&statusCode)))
+			io.WriteString(w, `{"error":{"code":"Error","message":"failed to poll"}}`)
+			return
+		}
+		io.WriteString(w, `{"status":"Succeeded","progress":100.0,"resourceLocation":"http://localhost/result.mp4"}`)
+	}))
+	defer server.Close()
+
+	client := &Client{}
+
+	for _, test := range []struct {
+		statusCode int32
+		polls      int32
+		fails      bool
+	}{
+		{http.StatusNotFound, 1, true},
+		{http.StatusUnauthorized, 1, true},
+		{http.StatusServiceUnavailable, 2, false},
+		{http.StatusTooManyRequests, 2, false},
+	} {
+		atomic.StoreInt32(&polls, 0)
+		atomic.StoreInt32(&statusCode, test.statusCode)
+
+		op := client.newOperation(OperationVideoStabilize, server.URL, "test-key")
+		op.Options = PollOptions{InitialDelay: time.Millisecond, Interval: time.Millisecond, MaxTries: 5}
+		err := op.Wait(context.Background())
+		if test.fails != (err != nil) || (test.fails && !isApiError(err, func(e *ApiError) bool { return e.StatusCode == int(test.statusCode) })) {
+			t.Errorf("%d: unexpected error: %v", test.statusCode, err)
+		}
+		if n := atomic.LoadInt32(&polls); n != test.polls {
+			t.Errorf("%d: polled %d times, expected %d", test.statusCode, n, test.polls)
+		}
+	}
+}
+
+func TestOperationResume(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Ocp-Apim-Subscription-Key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		io.WriteString(w, `{"status":"Succeeded","resourceLocation":"http://localhost/result.mp4"}`)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL, PollOptions: testPollOptions}
+
+	// marshal the operation as its handle
+	bytes, err := json.Marshal(client.newOperation(OperationVideoStabilize, server.URL+"/video/v1.0/operations/1", "test-key"))
+	if err != nil {
+		t.Fatalf("Failed to marshal operation: %s", err)
+	}
+	if string(bytes) != `{"kind":"video/stabilize","location":"`+server.URL+`/video/v1.0/operations/1"}` {
+		t.Errorf("Unexpected json: %s", string(bytes))
+	}
+
+	// resume with the stored handle
+	var handle OperationHandle
+	if err = json.Unmarshal(bytes, &handle); err != nil {
+		t.Fatalf("Failed to unmarshal handle: %s", err)
+	}
+	op, err := client.ResumeOperation(WestUS, "test-key", handle)
+	if err != nil {
+		t.Fatalf("ResumeOperation() failed: %s", err)
+	}
+	if op.Kind != OperationVideoStabilize || op.Kind.Service() != ServiceVideo {
+		t.Errorf("Unexpected operation kind: %s", op.Kind)
+	}
+	var fileUrl string
+	if err = op.waitResult(context.Background(), nil, &fileUrl); err != nil || fileUrl != "http://localhost/result.mp4" {
+		t.Errorf("Resumed operation = %s, %v", fileUrl, err)
+	}
+
+	// invalid handles
+	for _, handle := range []OperationHandle{
+		{Kind: "video/unknown", Location: server.URL + "/video/v1.0/operations/1"},
+		{Kind: OperationVideoStabilize, Location: "/video/v1.0/operations/1"},
+		{Kind: OperationVideoStabilize, Location: "http://attacker.example.com/video/v1.0/operations/1"},
+		{Kind: OperationVideoStabilize, Location: server.URL + "/face/v1.0/operations/1"},
+		{Kind: OperationVideoStabilize, Location: server.URL + "/video/v1.0/operations/1/../../detect"},
+		{Kind: OperationVideoStabilize, Location: server.URL + "/video/v1.0/operations/"},
+		{Kind: OperationVideoStabilize, Location: server.URL + "/video/v1.0/operations/1?redirect=http://attacker.example.com"},
+	} {
+		if _, err := client.ResumeOperation(WestUS, "test-key", handle); err == nil {
+			t.Errorf("ResumeOperation() should fail with handle: %+v", handle)
+		}
+	}
+}
+
+func TestOperationResumeLocation(t *testing.T) {
+	for _, tc := range []struct {
+		endpoint EndpointUrl
+		handle   OperationHandle
+		valid    bool
+	}{
+		// https url of the location or endpoint
+		{"", OperationHandle{OperationVideoStabilize, "https://westus.api.cognitive.microsoft.com/video/v1.0/operations/1", nil}, true},
+		{"", OperationHandle{OperationEmotionRecognizeVideo, "https://westus.api.cognitive.microsoft.com/emotion/v1.0/operations/1", nil}, true},
+		{"", OperationHandle{OperationComputerVisionRecognizeHandwritten, "https://westus.api.cognitive.microsoft.com/vision/v1.0/textOperations/1", nil}, true},
+		{EndpointUrl("https://my-resource.cognitiveservices.azure.com/proxy"), OperationHandle{OperationVideoThumbnail, "https://my-resource.cognitiveservices.azure.com/proxy/video/v1.0/operations/1", nil}, true},
+
+		// plain http, without BaseUrl
+		{"", OperationHandle{OperationVideoStabilize, "http://westus.api.cognitive.microsoft.com/video/v1.0/operations/1", nil}, false},
+		{EndpointUrl("http://localhost:8080"), OperationHandle{OperationVideoStabilize, "http://localhost:8080/video/v1.0/operations/1", nil}, false},
+
+		// other hosts
+		{"", OperationHandle{OperationVideoStabilize, "https://eastus.api.cognitive.microsoft.com/video/v1.0/operations/1", nil}, false},
+		{"", OperationHandle{OperationVideoStabilize, "https://westus.api.cognitive.microsoft.com.attacker.example.com/video/v1.0/operations/1", nil}, false},
+
+		// other paths
+		{"", OperationHandle{OperationVideoStabilize, "https://westus.api.cognitive.microsoft.com/emotion/v1.0/operations/1", nil}, false},
+		{"", OperationHandle{OperationComputerVisionRecognizeHandwritten, "https://westus.api.cognitive.microsoft.com/vision/v1.0/operations/1", nil}, false},
+		{EndpointUrl("https://my-resource.cognitiveservices.azure.com/proxy"), OperationHandle{OperationVideoThumbnail, "https://my-resource.cognitiveservices.azure.com/video/v1.0/operations/1", nil}, false},
+	} {
+		client := &Client{Endpoint: tc.endpoint}
+		if _, err := client.ResumeOperation(WestUS, "test-key", tc.handle); (err == nil) != tc.valid {
+			t.Errorf("ResumeOperation(%+v) with endpoint %q = %v, expected valid: %t", tc.handle, tc.endpoint, err, tc.valid)
+		}
+	}
+}
+
+func TestOperationSubmitWithoutLocation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+	if op, err := client.VideoStabilizeSubmit(context.Background(), "test-key", "http://localhost/video.mp4"); err == nil {
+		t.Errorf("VideoStabilizeSubmit() should fail without Operation-Location header, got: %+v", op)
+	}
+}
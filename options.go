@@ -0,0 +1,345 @@
+package cognitive
+
+// option for the APIs with options (eg. FaceDetectWithOptions(ctx, location, key, image, WithLandmarks(), WithAttributes(FaceAttributeAge, FaceAttributeGender)))
+//
+// options which are not applicable to an API fail with ParameterError, before any request is sent
+type Option func(o *options)
+
+// names of the APIs with options
+const (
+	apiFaceDetect                               = "FaceDetect"
+	apiFaceFindSimilar                          = "FaceFindSimilar"
+	apiFaceIdentify                             = "FaceIdentify"
+	apiFaceAddFaceToList                        = "FaceAddFaceToList"
+	apiFaceAddPersonFace                        = "FaceAddPersonFace"
+	apiComputerVisionAnalyzeImage               = "ComputerVisionAnalyzeImage"
+	apiComputerVisionDescribeImage              = "ComputerVisionDescribeImage"
+	apiComputerVisionGetThumbnail               = "ComputerVisionGetThumbnail"
+	apiComputerVisionOcr                        = "ComputerVisionOcr"
+	apiComputerVisionRecognizeHandwritten       = "ComputerVisionRecognizeHandwritten"
+	apiComputerVisionRecognizeHandwrittenSubmit = "ComputerVisionRecognizeHandwrittenSubmit"
+	apiEmotionRecognizeImage                    = "EmotionRecognizeImage"
+	apiEmotionRecognizeVideo                    = "EmotionRecognizeVideo"
+	apiEmotionRecognizeVideoSubmit              = "EmotionRecognizeVideoSubmit"
+	apiVideoFaceDetectTrack                     = "VideoFaceDetectTrack"
+	apiVideoFaceDetectTrackSubmit               = "VideoFaceDetectTrackSubmit"
+	apiVideoMotionDetect                        = "VideoMotionDetect"
+	apiVideoMotionDetectSubmit                  = "VideoMotionDetectSubmit"
+	apiVideoStabilize                           = "VideoStabilize"
+	apiVideoStabilizeSubmit                     = "VideoStabilizeSubmit"
+	apiVideoThumbnail                           = "VideoThumbnail"
+	apiVideoThumbnailSubmit                     = "VideoThumbnailSubmit"
+)
+
+// values of options
+type options struct {
+	api string // name of the API which options are given to
+	err error  // error of the first option which is not applicable to the API
+
+	// face
+	returnFaceId               bool
+	returnFaceLandmarks        bool
+	returnFaceAttributes       []FaceAttribute
+	faceListId                 string
+	faceIds                    []string
+	maxNumOfCandidatesReturned int
+	mode                       FindSimilarMode
+	confidenceThreshold        float64
+	userData                   string
+	targetFace                 Rectangle
+
+	// computer vision
+	visualFeatures    []VisualFeature
+	details           []Detail
+	language          string
+	maxCandidates     int
+	smartCropping     bool
+	detectOrientation bool
+	handwriting       bool
+
+	// emotion
+	rects       []Rectangle
+	outputStyle OutputStyle
+
+	// video
+	sensitivityLevel                 SensitivityLevel
+	frameSamplingValue               int
+	detectionZones                   [][]Point
+	detectLightChange                bool
+	mergeTimeThreshold               float64
+	maxMotionThumbnailDurationInSecs int
+	outputAudio                      bool
+	fadeInFadeOut                    bool
+
+	// long-running operations
+	progressNotifier func(status string, progress float32)
+}
+
+// apply given options for given API to the default values
+//
+// returns a ParameterError if any of them is not applicable to the API
+func newOptions(api string, opts []Option) (o *options, err error) {
+	o = &options{
+		api:           api,
+		returnFaceId:  true,
+		outputAudio:   true,
+		fadeInFadeOut: true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o, o.err
+}
+
+// options applicable to the APIs
+//
+// APIs which wait for the results of long-running operations (see longRunningApis) take the options of their submitting APIs and WithProgress()
+var applicableOptions = map[string][]string{
+	apiFaceDetect:                  {"WithoutFaceId", "WithLandmarks", "WithAttributes"},
+	apiFaceFindSimilar:             {"WithFaceList", "WithFaceIds", "WithMaxCandidates", "WithMode"},
+	apiFaceIdentify:                {"WithMaxCandidates", "WithConfidenceThreshold"},
+	apiFaceAddFaceToList:           {"WithUserData", "WithTargetFace"},
+	apiFaceAddPersonFace:           {"WithUserData", "WithTargetFace"},
+	apiComputerVisionAnalyzeImage:  {"WithVisualFeatures", "WithDetails", "WithLanguage"},
+	apiComputerVisionDescribeImage: {"WithMaxCandidates"},
+	apiComputerVisionGetThumbnail:  {"WithSmartCropping"},
+	apiComputerVisionOcr:           {"WithLanguage", "WithDetectOrientation"},
+	apiEmotionRecognizeImage:       {"WithFaceRectangles"},
+
+	// submitting long-running operations
+	apiComputerVisionRecognizeHandwrittenSubmit: {"WithHandwriting"},
+	apiEmotionRecognizeVideoSubmit:              {"WithOutputStyle"},
+	apiVideoFaceDetectTrackSubmit:               {},
+	apiVideoMotionDetectSubmit:                  {"WithSensitivity", "WithFrameSamplingValue", "WithDetectionZones", "WithDetectLightChange", "WithMergeTimeThreshold"},
+	apiVideoStabilizeSubmit:                     {},
+	apiVideoThumbnailSubmit:                     {"WithMaxThumbnailDuration", "WithoutAudio", "WithoutFadeInFadeOut"},
+}
+
+// APIs which wait for the results of long-running operations, with their submitting APIs
+var longRunningApis = map[string]string{
+	apiComputerVisionRecognizeHandwritten: apiComputerVisionRecognizeHandwrittenSubmit,
+	apiEmotionRecognizeVideo:              apiEmotionRecognizeVideoSubmit,
+	apiVideoFaceDetectTrack:               apiVideoFaceDetectTrackSubmit,
+	apiVideoMotionDetect:                  apiVideoMotionDetectSubmit,
+	apiVideoStabilize:                     apiVideoStabilizeSubmit,
+	apiVideoThumbnail:                     apiVideoThumbnailSubmit,
+}
+
+// check if the option with given name is applicable to the API
+func isApplicable(api, name string) bool {
+	if submitApi, exists := longRunningApis[api]; exists {
+		if name == "WithProgress" {
+			return true
+		}
+		api = submitApi
+	}
+	for _, option := range applicableOptions[api] {
+		if option == name {
+			return true
+		}
+	}
+	return false
+}
+
+// set an option with fn, if it is applicable to the API
+func (o *options) set(name string, fn func()) {
+	if isApplicable(o.api, name) {
+		fn()
+		return
+	}
+	if o.err == nil {
+		o.err = invalidParameter(name, "not applicable to %s", o.api)
+	}
+}
+
+// do not return face ids (Face API: Detect)
+func WithoutFaceId() Option {
+	return func(o *options) {
+		o.set("WithoutFaceId", func() { o.returnFaceId = false })
+	}
+}
+
+// return face landmarks (Face API: Detect)
+func WithLandmarks() Option {
+	return func(o *options) {
+		o.set("WithLandmarks", func() { o.returnFaceLandmarks = true })
+	}
+}
+
+// return face attributes, eg. FaceAttributeAge (Face API: Detect)
+func WithAttributes(attributes ...FaceAttribute) Option {
+	return func(o *options) {
+		o.set("WithAttributes", func() { o.returnFaceAttributes = append(o.returnFaceAttributes, attributes...) })
+	}
+}
+
+// find similar faces in the face list (Face API: Find Similar)
+func WithFaceList(faceListId string) Option {
+	return func(o *options) {
+		o.set("WithFaceList", func() { o.faceListId = faceListId })
+	}
+}
+
+// find similar faces among the faces (Face API: Find Similar)
+func WithFaceIds(faceIds ...string) Option {
+	return func(o *options) {
+		o.set("WithFaceIds", func() { o.faceIds = append(o.faceIds, faceIds...) })
+	}
+}
+
+// maximum number of candidates returned (Face API: Find Similar, Identify / Computer Vision API: Describe Image)
+func WithMaxCandidates(n int) Option {
+	return func(o *options) {
+		o.set("WithMaxCandidates", func() {
+			o.maxNumOfCandidatesReturned = n
+			o.maxCandidates = n
+		})
+	}
+}
+
+// FindSimilarMatchPerson or FindSimilarMatchFace (Face API: Find Similar)
+func WithMode(mode FindSimilarMode) Option {
+	return func(o *options) {
+		o.set("WithMode", func() { o.mode = mode })
+	}
+}
+
+// confidence threshold of identification, 0 - 1 (Face API: Identify)
+func WithConfidenceThreshold(threshold float64) Option {
+	return func(o *options) {
+		o.set("WithConfidenceThreshold", func() { o.confidenceThreshold = threshold })
+	}
+}
+
+// user data of the face (Face API: Add a Face to a Face List, Add a Person Face)
+func WithUserData(userData string) Option {
+	return func(o *options) {
+		o.set("WithUserData", func() { o.userData = userData })
+	}
+}
+
+// target face in the image (Face API: Add a Face to a Face List, Add a Person Face)
+func WithTargetFace(targetFace Rectangle) Option {
+	return func(o *options) {
+		o.set("WithTargetFace", func() { o.targetFace = targetFace })
+	}
+}
+
+// return visual features, eg. VisualFeatureTags (Computer Vision API: Analyze Image)
+func WithVisualFeatures(visualFeatures ...VisualFeature) Option {
+	return func(o *options) {
+		o.set("WithVisualFeatures", func() { o.visualFeatures = append(o.visualFeatures, visualFeatures...) })
+	}
+}
+
+// DetailCelebrities or DetailLandmarks (Computer Vision API: Analyze Image)
+func WithDetails(details ...Detail) Option {
+	return func(o *options) {
+		o.set("WithDetails", func() { o.details = append(o.details, details...) })
+	}
+}
+
+// language of the results (Computer Vision API: Analyze Image, OCR)
+func WithLanguage(language string) Option {
+	return func(o *options) {
+		o.set("WithLanguage", func() { o.language = language })
+	}
+}
+
+// crop thumbnails smartly (Computer Vision API: Get Thumbnail)
+func WithSmartCropping() Option {
+	return func(o *options) {
+		o.set("WithSmartCropping", func() { o.smartCropping = true })
+	}
+}
+
+// detect orientations of texts (Computer Vision API: OCR)
+func WithDetectOrientation() Option {
+	return func(o *options) {
+		o.set("WithDetectOrientation", func() { o.detectOrientation = true })
+	}
+}
+
+// recognize handwritten texts (Computer Vision API: Recognize Handwritten Text)
+func WithHandwriting() Option {
+	return func(o *options) {
+		o.set("WithHandwriting", func() { o.handwriting = true })
+	}
+}
+
+// rectangles of faces (Emotion API: Emotion Recognition)
+func WithFaceRectangles(rects ...Rectangle) Option {
+	return func(o *options) {
+		o.set("WithFaceRectangles", func() { o.rects = append(o.rects, rects...) })
+	}
+}
+
+// OutputStyleAggregate or OutputStylePerFrame (Emotion API: Emotion Recognition in Video)
+func WithOutputStyle(outputStyle OutputStyle) Option {
+	return func(o *options) {
+		o.set("WithOutputStyle", func() { o.outputStyle = outputStyle })
+	}
+}
+
+// SensitivityLow, SensitivityMedium, or SensitivityHigh (Video API: Motion Detection)
+func WithSensitivity(sensitivityLevel SensitivityLevel) Option {
+	return func(o *options) {
+		o.set("WithSensitivity", func() { o.sensitivityLevel = sensitivityLevel })
+	}
+}
+
+// 1 - 20 (Video API: Motion Detection)
+func WithFrameSamplingValue(frameSamplingValue int) Option {
+	return func(o *options) {
+		o.set("WithFrameSamplingValue", func() { o.frameSamplingValue = frameSamplingValue })
+	}
+}
+
+// zones for detecting motions (Video API: Motion Detection)
+func WithDetectionZones(detectionZones ...[]Point) Option {
+	return func(o *options) {
+		o.set("WithDetectionZones", func() { o.detectionZones = append(o.detectionZones, detectionZones...) })
+	}
+}
+
+// detect light changes (Video API: Motion Detection)
+func WithDetectLightChange() Option {
+	return func(o *options) {
+		o.set("WithDetectLightChange", func() { o.detectLightChange = true })
+	}
+}
+
+// time threshold for merging motions in seconds (Video API: Motion Detection)
+func WithMergeTimeThreshold(seconds float64) Option {
+	return func(o *options) {
+		o.set("WithMergeTimeThreshold", func() { o.mergeTimeThreshold = seconds })
+	}
+}
+
+// maximum duration of the motion thumbnail in seconds (Video API: Thumbnail)
+func WithMaxThumbnailDuration(seconds int) Option {
+	return func(o *options) {
+		o.set("WithMaxThumbnailDuration", func() { o.maxMotionThumbnailDurationInSecs = seconds })
+	}
+}
+
+// do not output audio (Video API: Thumbnail)
+func WithoutAudio() Option {
+	return func(o *options) {
+		o.set("WithoutAudio", func() { o.outputAudio = false })
+	}
+}
+
+// do not fade in/out (Video API: Thumbnail)
+func WithoutFadeInFadeOut() Option {
+	return func(o *options) {
+		o.set("WithoutFadeInFadeOut", func() { o.fadeInFadeOut = false })
+	}
+}
+
+// notify the progress of the long-running operation while waiting for its result, not for submitting (Computer Vision API: Recognize Handwritten Text / Emotion API: Emotion Recognition in Video / Video API: Face Detection and Tracking, Motion Detection, Stabilization, Thumbnail)
+func WithProgress(progressNotifier func(status string, progress float32)) Option {
+	return func(o *options) {
+		o.set("WithProgress", func() { o.progressNotifier = progressNotifier })
+	}
+}
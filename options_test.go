@@ -0,0 +1,216 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	var query url.Values
+	var requested int
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		requested++
+
+		switch r.URL.Path {
+		case "/face/v1.0/detect":
+			io.WriteString(w, `[{"faceId":"face-1","faceRectangle":{"left":1,"top":2,"width":3,"height":4}}]`)
+		case "/video/v1.0/detectmotion":
+			w.Header().Set("Operation-Location", server.URL+"/video/v1.0/operations/1")
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+
+	// defaults
+	if _, err := client.FaceDetectWithOptions(context.Background(), WestUS, "key", "http://localhost/face.jpg"); err != nil {
+		t.Fatalf("FaceDetectWithOptions() failed: %s", err)
+	}
+	if len(query) != 0 {
+		t.Errorf("Default values should not be sent: %v", query)
+	}
+
+	// with options
+	faces, err := client.FaceDetectWithOptions(context.Background(), WestUS, "key", "http://localhost/face.jpg",
+		WithoutFaceId(),
+		WithLandmarks(),
+		WithAttributes(FaceAttributeAge, FaceAttributeGender),
+		WithAttributes(FaceAttributeSmile),
+	)
+	if err != nil {
+		t.Fatalf("FaceDetectWithOptions() failed: %s", err)
+	}
+	if len(faces) != 1 || faces[0].FaceId != "face-1" {
+		t.Errorf("Unexpected result: %+v", faces)
+	}
+	if query.Get("returnFaceId") != "false" || query.Get("returnFaceLandmarks") != "true" || query.Get("returnFaceAttributes") != "age,gender,smile" {
+		t.Errorf("Unexpected params: %v", query)
+	}
+
+	op, err := client.VideoMotionDetectSubmitWithOptions(context.Background(), WestUS, "key", "http://localhost/video.mp4",
+		WithSensitivity(SensitivityHigh),
+		WithDetectLightChange(),
+		WithDetectionZones([]Point{{X: 0, Y: 0}, {X: 0.5, Y: 0}, {X: 0.5, Y: 0.5}}),
+	)
+	if err != nil {
+		t.Fatalf("VideoMotionDetectSubmitWithOptions() failed: %s", err)
+	}
+	if op.Location != server.URL+"/video/v1.0/operations/1" {
+		t.Errorf("Unexpected operation location: %s", op.Location)
+	}
+	if query.Get("sensitivityLevel") != "high" || query.Get("detectLightChange") != "true" || query.Get("detectionZones") != "0.000,0.000;0.500,0.000;0.500,0.500" {
+		t.Errorf("Unexpected params: %v", query)
+	}
+
+	// options which are not applicable fail before any request is sent
+	requested = 0
+	var paramErr *ParameterError
+	if _, err := client.VideoMotionDetectSubmitWithOptions(context.Background(), WestUS, "key", "http://localhost/video.mp4",
+		WithSensitivity(SensitivityHigh),
+		WithLandmarks(),
+	); !errors.As(err, &paramErr) || paramErr.Parameter != "WithLandmarks" || !errors.Is(err, ErrParameterInvalid) {
+		t.Errorf("Inapplicable option should fail with ParameterError: %v", err)
+	}
+	if _, err := client.VideoMotionDetectSubmitWithOptions(context.Background(), WestUS, "key", "http://localhost/video.mp4",
+		WithProgress(func(status string, progress float32) {}),
+	); !errors.As(err, &paramErr) || paramErr.Parameter != "WithProgress" {
+		t.Errorf("Progress notifier should fail for submitting: %v", err)
+	}
+	if _, err := client.FaceDetectWithOptions(context.Background(), WestUS, "key", "http://localhost/face.jpg",
+		WithSensitivity(SensitivityHigh),
+	); !errors.As(err, &paramErr) || paramErr.Parameter != "WithSensitivity" {
+		t.Errorf("Inapplicable option should fail with ParameterError: %v", err)
+	}
+	if requested != 0 {
+		t.Errorf("Requests should not be sent with inapplicable options: %d", requested)
+	}
+}
+
+func TestOptionsApplicability(t *testing.T) {
+	// APIs named in the comments of options
+	apis := map[string]string{
+		"Face API: Detect":                                apiFaceDetect,
+		"Face API: Find Similar":                          apiFaceFindSimilar,
+		"Face API: Identify":                              apiFaceIdentify,
+		"Face API: Add a Face to a Face List":             apiFaceAddFaceToList,
+		"Face API: Add a Person Face":                     apiFaceAddPersonFace,
+		"Computer Vision API: Analyze Image":              apiComputerVisionAnalyzeImage,
+		"Computer Vision API: Describe Image":             apiComputerVisionDescribeImage,
+		"Computer Vision API: Get Thumbnail":              apiComputerVisionGetThumbnail,
+		"Computer Vision API: OCR":                        apiComputerVisionOcr,
+		"Computer Vision API: Recognize Handwritten Text": apiComputerVisionRecognizeHandwritten,
+		"Emotion API: Emotion Recognition":                apiEmotionRecognizeImage,
+		"Emotion API: Emotion Recognition in Video":       apiEmotionRecognizeVideo,
+		"Video API: Face Detection and Tracking":          apiVideoFaceDetectTrack,
+		"Video API: Motion Detection":                     apiVideoMotionDetect,
+		"Video API: Stabilization":                        apiVideoStabilize,
+		"Video API: Thumbnail":                            apiVideoThumbnail,
+	}
+	all := []string{}
+	for api := range applicableOptions {
+		all = append(all, api)
+	}
+	for api := range longRunningApis {
+		all = append(all, api)
+	}
+
+	opts := map[string]Option{
+		"WithoutFaceId":            WithoutFaceId(),
+		"WithLandmarks":            WithLandmarks(),
+		"WithAttributes":           WithAttributes(FaceAttributeAge),
+		"WithFaceList":             WithFaceList("list-1"),
+		"WithFaceIds":              WithFaceIds("face-1"),
+		"WithMaxCandidates":        WithMaxCandidates(1),
+		"WithMode":                 WithMode(FindSimilarMatchFace),
+		"WithConfidenceThreshold":  WithConfidenceThreshold(0.5),
+		"WithUserData":             WithUserData("data"),
+		"WithTargetFace":           WithTargetFace(Rectangle{}),
+		"WithVisualFeatures":       WithVisualFeatures(VisualFeatureTags),
+		"WithDetails":              WithDetails(DetailLandmarks),
+		"WithLanguage":             WithLanguage("en"),
+		"WithSmartCropping":        WithSmartCropping(),
+		"WithDetectOrientation":    WithDetectOrientation(),
+		"WithHandwriting":          WithHandwriting(),
+		"WithFaceRectangles":       WithFaceRectangles(Rectangle{}),
+		"WithOutputStyle":          WithOutputStyle(OutputStylePerFrame),
+		"WithSensitivity":          WithSensitivity(SensitivityHigh),
+		"WithFrameSamplingValue":   WithFrameSamplingValue(2),
+		"WithDetectionZones":       WithDetectionZones([]Point{}),
+		"WithDetectLightChange":    WithDetectLightChange(),
+		"WithMergeTimeThreshold":   WithMergeTimeThreshold(1.0),
+		"WithMaxThumbnailDuration": WithMaxThumbnailDuration(1),
+		"WithoutAudio":             WithoutAudio(),
+		"WithoutFadeInFadeOut":     WithoutFadeInFadeOut(),
+		"WithProgress":             WithProgress(nil),
+	}
+	accepted := func(api string, opt Option) bool {
+		_, err := newOptions(api, []Option{opt})
+		return err == nil
+	}
+
+	// every option is accepted by the APIs in its comment, and rejected by at least one API
+	file, err := parser.ParseFile(token.NewFileSet(), "options.go", nil, parser.ParseComments)
+	if err != nil {
+		t.Fatalf("Failed to parse options.go: %s", err)
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil || !strings.HasPrefix(fn.Name.Name, "With") {
+			continue
+		}
+		name := fn.Name.Name
+
+		opt, exists := opts[name]
+		if !exists {
+			t.Errorf("%s is not tested", name)
+			continue
+		}
+
+		comment := strings.TrimSpace(fn.Doc.Text())
+		named := comment[strings.LastIndex(comment, "(")+1 : len(comment)-1]
+		for _, service := range strings.Split(named, " / ") {
+			prefix := service[:strings.Index(service, ": ")+2]
+			for _, apiName := range strings.Split(strings.TrimPrefix(service, prefix), ", ") {
+				api, exists := apis[prefix+apiName]
+				if !exists {
+					t.Errorf("Unknown API in the comment of %s: %s", name, prefix+apiName)
+				} else if !accepted(api, opt) {
+					t.Errorf("%s should be accepted by %s", name, api)
+				}
+			}
+		}
+
+		rejected := false
+		for _, api := range all {
+			rejected = rejected || !accepted(api, opt)
+		}
+		if !rejected {
+			t.Errorf("%s should be rejected by at least one API", name)
+		}
+	}
+
+	// APIs waiting for long-running operations take the options of their submitting APIs, and WithProgress()
+	for api, submitApi := range longRunningApis {
+		for name, opt := range opts {
+			if name == "WithProgress" {
+				if !accepted(api, opt) || accepted(submitApi, opt) {
+					t.Errorf("%s should be accepted by %s only, not by %s", name, api, submitApi)
+				}
+			} else if accepted(api, opt) != accepted(submitApi, opt) {
+				t.Errorf("%s should be accepted (or rejected) by both %s and %s", name, api, submitApi)
+			}
+		}
+	}
+}
@@ -0,0 +1,18 @@
+module github.com/meinside/ms-cognitive-services-go/otelcognitive
+
+go 1.21
+
+require (
+	github.com/meinside/ms-cognitive-services-go v0.0.0-20261017182922-2404eef002c7
+	go.opentelemetry.io/otel v1.24.0
+	go.opentelemetry.io/otel/metric v1.24.0
+	go.opentelemetry.io/otel/sdk v1.24.0
+	go.opentelemetry.io/otel/sdk/metric v1.24.0
+	go.opentelemetry.io/otel/trace v1.24.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.1 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	golang.org/x/sys v0.17.0 // indirect
+)
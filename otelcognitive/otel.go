@@ -0,0 +1,117 @@
+package otelcognitive
+
+// OpenTelemetry instrumentation for cognitive.Client
+//
+// creates a span for each API operation, and records counters and latencies of them:
+//
+//	instrumentation, err := otelcognitive.New(otel.GetTracerProvider(), otel.GetMeterProvider())
+//	client := &cognitive.Client{Instrumentation: instrumentation}
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/meinside/ms-cognitive-services-go"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// name of the instrumentation scope
+const ScopeName = "github.com/meinside/ms-cognitive-services-go"
+
+// attribute keys
+const (
+	AttributeService    = attribute.Key("cognitive.service")
+	AttributeOperation  = attribute.Key("cognitive.operation")
+	AttributeRegion     = attribute.Key("cognitive.region")
+	AttributeRetries    = attribute.Key("cognitive.retries")
+	AttributeMethod     = attribute.Key("http.request.method")
+	AttributeStatusCode = attribute.Key("http.response.status_code")
+	AttributeUrl        = attribute.Key("url.full")
+)
+
+// metric names
+const (
+	MetricOperations = "cognitive.client.operations"         // counter of operations
+	MetricErrors     = "cognitive.client.operation.errors"   // counter of failed operations (failed requests or error responses)
+	MetricDuration   = "cognitive.client.operation.duration" // histogram of durations of operations in seconds
+)
+
+// instrumentation which implements cognitive.Instrumentation with OpenTelemetry
+type Instrumentation struct {
+	tracer     trace.Tracer
+	operations metric.Int64Counter
+	errors     metric.Int64Counter
+	duration   metric.Float64Histogram
+}
+
+// create a new instrumentation with given tracer and meter providers
+func New(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (i *Instrumentation, err error) {
+	meter := meterProvider.Meter(ScopeName)
+
+	i = &Instrumentation{
+		tracer: tracerProvider.Tracer(ScopeName),
+	}
+	if i.operations, err = meter.Int64Counter(MetricOperations, metric.WithDescription("Number of API operations"), metric.WithUnit("{operation}")); err != nil {
+		return nil, err
+	}
+	if i.errors, err = meter.Int64Counter(MetricErrors, metric.WithDescription("Number of failed API operations"), metric.WithUnit("{operation}")); err != nil {
+		return nil, err
+	}
+	if i.duration, err = meter.Float64Histogram(MetricDuration, metric.WithDescription("Duration of API operations"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	return i, nil
+}
+
+// start a span for given API operation (implements cognitive.Instrumentation)
+func (i *Instrumentation) StartOperation(ctx context.Context, info cognitive.OperationInfo) (context.Context, func(cognitive.OperationResult)) {
+	name := info.Name
+	if name == "" {
+		name = fmt.Sprintf("cognitive %s", info.Method)
+	}
+
+	attrs := []attribute.KeyValue{
+		AttributeService.String(string(info.Service)),
+		AttributeOperation.String(info.Name),
+		AttributeRegion.String(info.Region),
+	}
+
+	ctx, span := i.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(AttributeMethod.String(info.Method), AttributeUrl.String(info.Url)),
+	)
+
+	return ctx, func(result cognitive.OperationResult) {
+		span.SetAttributes(AttributeRetries.Int(result.Retries))
+		if result.StatusCode != 0 {
+			span.SetAttributes(AttributeStatusCode.Int(result.StatusCode))
+		}
+
+		failed := true
+		if result.Err != nil {
+			span.RecordError(result.Err)
+			span.SetStatus(codes.Error, result.Err.Error())
+		} else if result.StatusCode >= 400 {
+			span.SetStatus(codes.Error, fmt.Sprintf("http status %d", result.StatusCode))
+		} else {
+			failed = false
+		}
+		span.End()
+
+		if result.StatusCode != 0 {
+			attrs = append(attrs, AttributeStatusCode.Int(result.StatusCode))
+		}
+		options := metric.WithAttributes(attrs...)
+
+		i.operations.Add(ctx, 1, options)
+		if failed {
+			i.errors.Add(ctx, 1, options)
+		}
+		i.duration.Record(ctx, result.Duration.Seconds(), options)
+	}
+}
@@ -0,0 +1,112 @@
+package otelcognitive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/meinside/ms-cognitive-services-go"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentation(t *testing.T) {
+	var identifies int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/face/v1.0/identify":
+			if atomic.AddInt32(&identifies, 1) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			io.WriteString(w, `[]`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error":{"code":"PersonGroupNotFound","message":"Person group is not found."}}`)
+		}
+	}))
+	defer server.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	instrumentation, err := New(
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	if err != nil {
+		t.Fatalf("New() failed: %s", err)
+	}
+
+	client := &cognitive.Client{
+		BaseUrl:         server.URL,
+		Retry:           &cognitive.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Instrumentation: instrumentation,
+	}
+	if _, err := client.FaceIdentify(context.Background(), cognitive.WestUS, "test-key", []string{"face-1"}, "group-1", 1, 0.5); err != nil {
+		t.Errorf("FaceIdentify() failed: %s", err)
+	}
+	if _, err := client.FaceGetPersonGroup(context.Background(), cognitive.WestUS, "test-key", "not-existing"); !cognitive.IsNotFound(err) {
+		t.Errorf("FaceGetPersonGroup() should fail with not found, got: %v", err)
+	}
+
+	// spans
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("Unexpected spans: %+v", spans)
+	}
+	if s := spans[0]; s.Name != "FaceIdentify" || s.Status.Code == codes.Error ||
+		!hasAttribute(s.Attributes, AttributeRetries.Int(1)) ||
+		!hasAttribute(s.Attributes, AttributeStatusCode.Int(http.StatusOK)) ||
+		!hasAttribute(s.Attributes, AttributeService.String(string(cognitive.ServiceFace))) {
+		t.Errorf("Unexpected span: %s, %+v, %+v", s.Name, s.Status, s.Attributes)
+	}
+	if s := spans[1]; s.Name != "FaceGetPersonGroup" || s.Status.Code != codes.Error ||
+		!hasAttribute(s.Attributes, AttributeStatusCode.Int(http.StatusNotFound)) {
+		t.Errorf("Unexpected span: %s, %+v, %+v", s.Name, s.Status, s.Attributes)
+	}
+
+	// metrics
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("Collect() failed: %s", err)
+	}
+	sums := map[string]int64{}
+	histograms := map[string]uint64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			switch data := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, dp := range data.DataPoints {
+					sums[m.Name] += dp.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, dp := range data.DataPoints {
+					histograms[m.Name] += dp.Count
+				}
+			}
+		}
+	}
+	if sums[MetricOperations] != 2 || sums[MetricErrors] != 1 || histograms[MetricDuration] != 2 {
+		t.Errorf("Unexpected metrics: %v, %v", sums, histograms)
+	}
+}
+
+// check if given attributes contain the attribute
+func hasAttribute(attrs []attribute.KeyValue, attr attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == attr {
+			return true
+		}
+	}
+	return false
+}
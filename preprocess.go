@@ -0,0 +1,352 @@
+package cognitive
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io/ioutil"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// options for preprocessing images before uploading them
+//
+// only applied to images in bytes arrays or local files (not urls or streams),
+// and coordinates in the results (eg. face rectangles, landmarks, OCR bounding boxes) are mapped back to the original images
+// (in their displayed orientations, if AutoOrient is set).
+type PreprocessOptions struct {
+	Downscale   bool // downscale and re-encode jpeg/png images which exceed the size or dimension limits of endpoints
+	AutoOrient  bool // rotate/flip jpeg images with EXIF orientation, so that coordinates in the results are in the displayed orientation
+	JpegQuality int  // quality of re-encoded jpeg images, 1 ~ 100 (default: DefaultJpegQuality)
+}
+
+// default values of PreprocessOptions
+const (
+	DefaultJpegQuality = 90
+)
+
+// maximum number of re-encodings for fitting an image into the size limit
+const maxDownscaleAttempts = 8
+
+// transform of coordinates between an original image and its uploaded (preprocessed) one
+//
+// nil means that the image was uploaded as it is
+type imageTransform struct {
+	scaleX float64 // width of the original / width of the uploaded
+	scaleY float64 // height of the original / height of the uploaded
+}
+
+// create a transform from given scale factors (nil if not scaled)
+func newImageTransform(scale []float64) *imageTransform {
+	if len(scale) != 2 || scale[0] <= 0 || scale[1] <= 0 || (scale[0] == 1.0 && scale[1] == 1.0) {
+		return nil
+	}
+	return &imageTransform{scaleX: scale[0], scaleY: scale[1]}
+}
+
+// scale factors (x, y) of the transform (nil if not scaled)
+func (t *imageTransform) scale() []float64 {
+	if t == nil {
+		return nil
+	}
+	return []float64{t.scaleX, t.scaleY}
+}
+
+// map given x, y coordinates from the uploaded image to the original one
+func (t *imageTransform) xy(x, y float64) (float64, float64) {
+	if t == nil {
+		return x, y
+	}
+	return x * t.scaleX, y * t.scaleY
+}
+
+// map given rectangle from the uploaded image to the original one
+func (t *imageTransform) rect(r Rectangle) Rectangle {
+	if t == nil {
+		return r
+	}
+	return Rectangle{
+		Left:   int(math.Round(float64(r.Left) * t.scaleX)),
+		Top:    int(math.Round(float64(r.Top) * t.scaleY)),
+		Width:  int(math.Round(float64(r.Width) * t.scaleX)),
+		Height: int(math.Round(float64(r.Height) * t.scaleY)),
+	}
+}
+
+// map given rectangle from the original image to the uploaded one
+func (t *imageTransform) inverseRect(r Rectangle) Rectangle {
+	if t == nil {
+		return r
+	}
+	return (&imageTransform{scaleX: 1 / t.scaleX, scaleY: 1 / t.scaleY}).rect(r)
+}
+
+// map given point from the uploaded image to the original one
+func (t *imageTransform) point(p Point) Point {
+	x, y := t.xy(p.X, p.Y)
+	return Point{X: x, Y: y}
+}
+
+// map given bounding box (in format of "left,top,width,height") from the uploaded image to the original one
+func (t *imageTransform) box(box string) string {
+	values := strings.Split(box, ",")
+	if t == nil || len(values) != 4 {
+		return box
+	}
+
+	var r [4]int
+	for i, v := range values {
+		var err error
+		if r[i], err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+			return box
+		}
+	}
+
+	rect := t.rect(Rectangle{Left: r[0], Top: r[1], Width: r[2], Height: r[3]})
+	return fmt.Sprintf("%d,%d,%d,%d", rect.Left, rect.Top, rect.Width, rect.Height)
+}
+
+// map given polygon (in format of [x1, y1, x2, y2, ...]) from the uploaded image to the original one
+func (t *imageTransform) polygon(polygon []int) []int {
+	if t == nil {
+		return polygon
+	}
+
+	mapped := make([]int, len(polygon))
+	for i := 0; i+1 < len(polygon); i += 2 {
+		x, y := t.xy(float64(polygon[i]), float64(polygon[i+1]))
+		mapped[i], mapped[i+1] = int(math.Round(x)), int(math.Round(y))
+	}
+	return mapped
+}
+
+// map given width and height from the uploaded image to the original one
+func (t *imageTransform) size(width, height int) (int, int) {
+	w, h := t.xy(float64(width), float64(height))
+	return int(math.Round(w)), int(math.Round(h))
+}
+
+// results with coordinates which can be mapped back to the original images
+type coordinatesMapper interface {
+	mapCoordinates(t *imageTransform)
+}
+
+// preprocess given image argument for the API url with the client's PreprocessOptions
+//
+// returns the argument as it is (with nil transform) if it doesn't need to be, or cannot be preprocessed
+func (c *Client) preprocessImage(apiUrl string, arg interface{}) (preprocessed interface{}, transform *imageTransform, err error) {
+	options := c.Preprocess
+	if options == nil || (!options.Downscale && !options.AutoOrient) {
+		return arg, nil, nil
+	}
+
+	// only bytes arrays and local files
+	media, err := mediaOf(arg)
+	if err != nil || (media.data == nil && media.path == "") {
+		return arg, nil, nil
+	}
+	data := media.data
+	if data == nil {
+		if data, err = ioutil.ReadFile(media.path); err != nil {
+			return arg, nil, nil // will fail while validating
+		}
+	}
+
+	// only jpeg and png images which exceed the limits, or have EXIF orientations
+	format := detectFormat(data)
+	if format != FormatJPEG && format != FormatPNG {
+		return arg, nil, nil
+	}
+	limit := mediaLimitOf(apiUrl, MediaImage)
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return arg, nil, nil // will fail while validating
+	}
+	orientation := orientationNormal
+	if options.AutoOrient && format == FormatJPEG {
+		orientation = exifOrientation(data)
+	}
+	if orientationSwapsSize(orientation) { // dimensions as displayed
+		config.Width, config.Height = config.Height, config.Width
+	}
+	scale := 1.0
+	if options.Downscale {
+		if limit.maxWidth > 0 && config.Width > limit.maxWidth {
+			scale = math.Min(scale, float64(limit.maxWidth)/float64(config.Width))
+		}
+		if limit.maxHeight > 0 && config.Height > limit.maxHeight {
+			scale = math.Min(scale, float64(limit.maxHeight)/float64(config.Height))
+		}
+	}
+	exceeded := options.Downscale && limit.maxSize > 0 && int64(len(data)) > limit.maxSize
+	if scale == 1.0 && !exceeded && orientation == orientationNormal {
+		return arg, nil, nil
+	}
+
+	var img image.Image
+	if img, _, err = image.Decode(bytes.NewReader(data)); err != nil {
+		return nil, nil, &MediaError{Media: media.String(), Message: fmt.Sprintf("failed to decode %s for preprocessing", format), Err: err}
+	}
+	img = orientImage(img, orientation)
+
+	for attempt := 0; attempt < maxDownscaleAttempts; attempt++ {
+		width := int(math.Max(1, math.Floor(float64(config.Width)*scale)))
+		height := int(math.Max(1, math.Floor(float64(config.Height)*scale)))
+
+		resized := img
+		if width != config.Width || height != config.Height {
+			resized = resizeImage(img, width, height)
+		}
+
+		var encoded []byte
+		if encoded, err = options.encode(resized, format); err != nil {
+			return nil, nil, &MediaError{Media: media.String(), Message: fmt.Sprintf("failed to encode %s for preprocessing", format), Err: err}
+		}
+
+		if !options.Downscale || limit.maxSize <= 0 || int64(len(encoded)) <= limit.maxSize {
+			return FromBytes(encoded), newImageTransform([]float64{
+				float64(config.Width) / float64(width),
+				float64(config.Height) / float64(height),
+			}), nil
+		}
+
+		// shrink more, in proportion to the exceeded size
+		scale *= math.Sqrt(float64(limit.maxSize)/float64(len(encoded))) * 0.95
+	}
+
+	return arg, nil, nil // will fail while validating
+}
+
+// encode given image in the format
+func (o *PreprocessOptions) encode(img image.Image, format string) (encoded []byte, err error) {
+	var buf bytes.Buffer
+	if format == FormatPNG {
+		err = png.Encode(&buf, img)
+	} else {
+		quality := o.JpegQuality
+		if quality <= 0 || quality > 100 {
+			quality = DefaultJpegQuality
+		}
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+	}
+	return buf.Bytes(), err
+}
+
+// resize given image to width x height, averaging the source pixels of each destination pixel (box filter)
+func resizeImage(src image.Image, width, height int) *image.RGBA {
+	bounds := src.Bounds()
+	rgba, ok := src.(*image.RGBA)
+	if !ok {
+		rgba = image.NewRGBA(bounds)
+		draw.Draw(rgba, bounds, src, bounds.Min, draw.Src)
+	}
+
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := y * srcHeight / height
+		y1 := (y + 1) * srcHeight / height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := x * srcWidth / width
+			x1 := (x + 1) * srcWidth / width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				offset := rgba.PixOffset(bounds.Min.X+x0, bounds.Min.Y+sy)
+				for sx := x0; sx < x1; sx++ {
+					for i := 0; i < 4; i++ {
+						sum[i] += int(rgba.Pix[offset+i])
+					}
+					offset += 4
+				}
+			}
+
+			n := (y1 - y0) * (x1 - x0)
+			offset := dst.PixOffset(x, y)
+			for i := 0; i < 4; i++ {
+				dst.Pix[offset+i] = uint8(sum[i] / n)
+			}
+		}
+	}
+	return dst
+}
+
+func (r *FaceDetectResult) mapCoordinates(t *imageTransform) {
+	r.FaceRectangle = t.rect(r.FaceRectangle)
+	if r.FaceLandmarks != nil {
+		for _, point := range r.FaceLandmarks.pointers() {
+			*point = t.point(*point)
+		}
+		for name, point := range r.FaceLandmarks.Extra {
+			r.FaceLandmarks.Extra[name] = t.point(point)
+		}
+	}
+}
+
+func (e *Emotion) mapCoordinates(t *imageTransform) {
+	e.FaceRectangle = t.rect(e.FaceRectangle)
+}
+
+func (r *ComputerVisionImageAnalyzeResult) mapCoordinates(t *imageTransform) {
+	for i := range r.Categories {
+		for j := range r.Categories[i].Detail.Celebrities {
+			r.Categories[i].Detail.Celebrities[j].FaceRectangle = t.rect(r.Categories[i].Detail.Celebrities[j].FaceRectangle)
+		}
+	}
+	for i := range r.Faces {
+		r.Faces[i].FaceRectangle = t.rect(r.Faces[i].FaceRectangle)
+	}
+	r.Metadata.Width, r.Metadata.Height = t.size(r.Metadata.Width, r.Metadata.Height)
+}
+
+func (r *ComputerVisionImageDescribeResult) mapCoordinates(t *imageTransform) {
+	r.Metadata.Width, r.Metadata.Height = t.size(r.Metadata.Width, r.Metadata.Height)
+}
+
+func (r *ComputerVisionDomainSpecificResult) mapCoordinates(t *imageTransform) {
+	for _, results := range r.Result {
+		for i := range results {
+			results[i].FaceRectangle = t.rect(results[i].FaceRectangle)
+		}
+	}
+	r.Metadata.Width, r.Metadata.Height = t.size(r.Metadata.Width, r.Metadata.Height)
+}
+
+func (r *ComputerVisionOcrResult) mapCoordinates(t *imageTransform) {
+	for i := range r.Regions {
+		region := &r.Regions[i]
+		region.BoundingBox = t.box(region.BoundingBox)
+		for j := range region.Lines {
+			line := &region.Lines[j]
+			line.BoundingBox = t.box(line.BoundingBox)
+			for k := range line.Words {
+				line.Words[k].BoundingBox = t.box(line.Words[k].BoundingBox)
+			}
+		}
+	}
+}
+
+func (r *ComputerVisionHandwrittenProcessingResult) mapCoordinates(t *imageTransform) {
+	for i := range r.Lines {
+		line := &r.Lines[i]
+		line.BoundingBox = t.polygon(line.BoundingBox)
+		for j := range line.Words {
+			line.Words[j].BoundingBox = t.polygon(line.Words[j].BoundingBox)
+		}
+	}
+}
+
+func (r *ComputerVisionTagImageResult) mapCoordinates(t *imageTransform) {
+	r.Metadata.Width, r.Metadata.Height = t.size(r.Metadata.Width, r.Metadata.Height)
+}
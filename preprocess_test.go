@@ -0,0 +1,101 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"image"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestImageTransform(t *testing.T) {
+	transform := newImageTransform([]float64{2.0, 4.0})
+
+	if rect := transform.rect(Rectangle{Left: 1, Top: 2, Width: 3, Height: 4}); rect != (Rectangle{Left: 2, Top: 8, Width: 6, Height: 16}) {
+		t.Errorf("Unexpected rect: %+v", rect)
+	}
+	if rect := transform.inverseRect(Rectangle{Left: 2, Top: 8, Width: 6, Height: 16}); rect != (Rectangle{Left: 1, Top: 2, Width: 3, Height: 4}) {
+		t.Errorf("Unexpected inverse rect: %+v", rect)
+	}
+	if point := transform.point(Point{X: 1.5, Y: 2.5}); point != (Point{X: 3, Y: 10}) {
+		t.Errorf("Unexpected point: %+v", point)
+	}
+	if box := transform.box("1,2,3,4"); box != "2,8,6,16" {
+		t.Errorf("Unexpected box: %s", box)
+	}
+	if polygon := transform.polygon([]int{1, 1, 2, 1, 2, 2, 1, 2}); !reflect.DeepEqual(polygon, []int{2, 4, 4, 4, 4, 8, 2, 8}) {
+		t.Errorf("Unexpected polygon: %v", polygon)
+	}
+
+	// no transform
+	var none *imageTransform
+	if box := none.box("1,2,3,4"); box != "1,2,3,4" {
+		t.Errorf("Unexpected box: %s", box)
+	}
+	if newImageTransform([]float64{1.0, 1.0}) != nil || newImageTransform(nil) != nil {
+		t.Errorf("Transforms without scaling should be nil")
+	}
+}
+
+func TestPreprocessDownscale(t *testing.T) {
+	original := testPng(t, 5000, 100)
+
+	var uploaded image.Config
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		uploaded, _, _ = image.DecodeConfig(bytes.NewReader(body))
+
+		switch r.URL.Path {
+		case "/face/v1.0/detect":
+			io.WriteString(w, `[{"faceId":"face-1","faceRectangle":{"left":100,"top":10,"width":40,"height":40},"faceLandmarks":{"noseTip":{"x":120,"y":30}}}]`)
+		case "/vision/v1.0/recognizeText":
+			w.Header().Set("Operation-Location", server.URL+"/vision/v1.0/textOperations/1")
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	// not downscaled without options
+	client := &Client{BaseUrl: server.URL}
+	if _, err := client.FaceDetect(context.Background(), WestUS, "test-key", original, true, true, nil); err == nil {
+		t.Errorf("FaceDetect() should fail with a too large image")
+	}
+
+	// downscaled, and coordinates are mapped back
+	client.Preprocess = &PreprocessOptions{Downscale: true}
+	if result, err := client.FaceDetect(context.Background(), WestUS, "test-key", original, true, true, nil); err == nil {
+		if uploaded.Width > 4096 || uploaded.Height >= 100 {
+			t.Errorf("Image was not downscaled: %dx%d", uploaded.Width, uploaded.Height)
+		}
+
+		scaleX, scaleY := 5000/float64(uploaded.Width), 100/float64(uploaded.Height)
+		if rect := result[0].FaceRectangle; rect.Left <= 100 || rect.Width <= 40 || rect.Top != int(10*scaleY+0.5) {
+			t.Errorf("Face rectangle was not mapped (scale: %.3f, %.3f): %+v", scaleX, scaleY, rect)
+		}
+		if nose := result[0].FaceLandmarks.NoseTip; nose.X != 120*scaleX || nose.Y != 30*scaleY {
+			t.Errorf("Landmark was not mapped (scale: %.3f, %.3f): %+v", scaleX, scaleY, nose)
+		}
+	} else {
+		t.Errorf("FaceDetect() failed: %s", err)
+	}
+
+	// scale of submitted operation is kept in its handle
+	if op, err := client.ComputerVisionRecognizeHandwrittenSubmit(context.Background(), WestUS, "test-key", original, true); err == nil {
+		bytes, _ := json.Marshal(op)
+
+		var handle OperationHandle
+		if err := json.Unmarshal(bytes, &handle); err != nil || len(handle.Scale) != 2 || handle.Scale[0] <= 1.0 {
+			t.Errorf("Unexpected handle: %s", string(bytes))
+		}
+		if resumed, err := client.ResumeOperation(WestUS, "test-key", handle); err != nil || !reflect.DeepEqual(resumed.Handle(), op.Handle()) {
+			t.Errorf("Resumed operation has a different handle: %+v, %v", resumed, err)
+		}
+	} else {
+		t.Errorf("ComputerVisionRecognizeHandwrittenSubmit() failed: %s", err)
+	}
+}
@@ -0,0 +1,240 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// services of the APIs
+type Service string
+
+const (
+	ServiceFace           Service = "face"
+	ServiceComputerVision Service = "vision"
+	ServiceEmotion        Service = "emotion"
+	ServiceVideo          Service = "video"
+)
+
+// all services
+var Services = []Service{
+	ServiceFace, ServiceComputerVision, ServiceEmotion, ServiceVideo,
+}
+
+// version segment of the API paths
+const apiVersion = "v1.0"
+
+// get the service of given API url (eg. "https://westus.api.cognitive.microsoft.com/face/v1.0/detect" => ServiceFace)
+//
+// base urls with path prefixes are also handled (eg. "http://localhost:8080/proxy/face/v1.0/detect" => ServiceFace),
+// and an empty string is returned if it is unknown
+func serviceOf(apiUrl *url.URL) Service {
+	segments := strings.Split(strings.Trim(apiUrl.Path, "/"), "/")
+
+	// the segment right before the version
+	for i := 1; i < len(segments); i++ {
+		if segments[i] == apiVersion {
+			return Service(segments[i-1])
+		}
+	}
+
+	// or the last known one
+	for i := len(segments) - 1; i >= 0; i-- {
+		for _, service := range Services {
+			if segments[i] == string(service) {
+				return service
+			}
+		}
+	}
+	return ""
+}
+
+// errors returned from RateLimiter
+var (
+	ErrRateLimitExceeded    = errors.New("client-side rate limit exceeded")
+	ErrMonthlyLimitExceeded = errors.New("client-side monthly limit exceeded")
+)
+
+// modes of RateLimiter
+type RateLimitMode int
+
+const (
+	RateLimitWait RateLimitMode = iota // wait until a transaction is available
+	RateLimitFail                      // fail immediately with ErrRateLimitExceeded
+)
+
+// limits of RateLimiter
+type RateLimit struct {
+	PerSecond float64       // transactions per second (0: unlimited)
+	Burst     int           // maximum number of transactions at once (default: PerSecond, rounded up)
+	PerMonth  int64         // transactions per calendar month (0: unlimited)
+	Mode      RateLimitMode // RateLimitWait (default) or RateLimitFail
+}
+
+// remaining budget of RateLimiter
+type RateLimitBudget struct {
+	Transactions float64 // transactions available right now
+	Month        int64   // transactions remaining in this month (-1 if unlimited)
+}
+
+// token bucket rate limiter, safe for concurrent use
+//
+// can be attached to a service and a subscription key with Client.SetRateLimiter()
+type RateLimiter struct {
+	limit RateLimit
+	now   func() time.Time
+
+	mu        sync.Mutex
+	tokens    float64
+	last      time.Time
+	month     time.Time // first day of current month
+	usedMonth int64
+}
+
+// create a new rate limiter with given limits
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.Burst <= 0 {
+		limit.Burst = int(math.Ceil(limit.PerSecond))
+	}
+	if limit.Burst <= 0 {
+		limit.Burst = 1
+	}
+
+	return &RateLimiter{
+		limit:  limit,
+		now:    time.Now,
+		tokens: float64(limit.Burst),
+	}
+}
+
+// wait for a transaction to be available (or fail, in RateLimitFail mode)
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		delay, err := l.reserve()
+		if err != nil || delay <= 0 {
+			return err
+		}
+
+		if err = sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// get the remaining budget
+func (l *RateLimiter) Remaining() RateLimitBudget {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	budget := RateLimitBudget{
+		Transactions: l.tokens,
+		Month:        -1,
+	}
+	if l.limit.PerSecond <= 0 {
+		budget.Transactions = math.Inf(1)
+	}
+	if l.limit.PerMonth > 0 {
+		budget.Month = l.limit.PerMonth - l.usedMonth
+	}
+	return budget
+}
+
+// take a transaction, or return the delay before the next one is available
+func (l *RateLimiter) reserve() (delay time.Duration, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+
+	if l.limit.PerMonth > 0 && l.usedMonth >= l.limit.PerMonth {
+		return 0, ErrMonthlyLimitExceeded
+	}
+
+	if l.limit.PerSecond > 0 {
+		if l.tokens < 1.0 {
+			if l.limit.Mode == RateLimitFail {
+				return 0, ErrRateLimitExceeded
+			}
+			return time.Duration((1.0 - l.tokens) / l.limit.PerSecond * float64(time.Second)), nil
+		}
+		l.tokens -= 1.0
+	}
+	l.usedMonth++
+
+	return 0, nil
+}
+
+// refill tokens for the elapsed time, and reset monthly usage when a new month begins
+func (l *RateLimiter) refill() {
+	now := l.now()
+
+	if month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC); !month.Equal(l.month) {
+		l.month = month
+		l.usedMonth = 0
+	}
+
+	if !l.last.IsZero() && l.limit.PerSecond > 0 {
+		l.tokens = math.Min(float64(l.limit.Burst), l.tokens+now.Sub(l.last).Seconds()*l.limit.PerSecond)
+	}
+	l.last = now
+}
+
+// key for rate limiters
+type rateLimiterKey struct {
+	service Service
+	key     string
+}
+
+// attach a rate limiter to given service and subscription key
+//
+// for requests without subscription keys (eg. authenticated with TokenCredential), key is the host of their endpoint (eg. "my-resource.cognitiveservices.azure.com").
+//
+// if key is empty, the limiter is applied to all keys of the service which have no limiter of their own.
+// if limiter is nil, the limiter attached before will be removed.
+func (c *Client) SetRateLimiter(service Service, key string, limiter *RateLimiter) {
+	c.limitersLock.Lock()
+	defer c.limitersLock.Unlock()
+
+	if c.limiters == nil {
+		c.limiters = map[rateLimiterKey]*RateLimiter{}
+	}
+
+	if limiter == nil {
+		delete(c.limiters, rateLimiterKey{service, key})
+	} else {
+		c.limiters[rateLimiterKey{service, key}] = limiter
+	}
+}
+
+// middleware which waits for the rate limiter of each request's service and subscription key
+func (c *Client) rateLimitMiddleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			id, _ := resourceIdOf(req)
+			if limiter := c.RateLimiter(serviceOf(req.URL), id); limiter != nil {
+				if err := limiter.Wait(req.Context()); err != nil {
+					return nil, err
+				}
+			}
+			return next(req)
+		}
+	}
+}
+
+// get the rate limiter for given service and subscription key (or host of the endpoint, for requests without subscription keys) (nil if none)
+func (c *Client) RateLimiter(service Service, key string) *RateLimiter {
+	c.limitersLock.RLock()
+	defer c.limitersLock.RUnlock()
+
+	if limiter, exists := c.limiters[rateLimiterKey{service, key}]; exists {
+		return limiter
+	}
+	return c.limiters[rateLimiterKey{service, ""}]
+}
@@ -0,0 +1,158 @@
+package cognitive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestServiceOf(t *testing.T) {
+	for rawUrl, expected := range map[string]Service{
+		"https://westus.api.cognitive.microsoft.com/face/v1.0/detect":           ServiceFace,
+		"https://westus.api.cognitive.microsoft.com/vision/v1.0/analyze":        ServiceComputerVision,
+		"https://westus.api.cognitive.microsoft.com/emotion/v1.0/recognize":     ServiceEmotion,
+		"https://westus.api.cognitive.microsoft.com/video/v1.0/operations/1234": ServiceVideo,
+		"http://localhost:8080/proxy/face/v1.0/detect":                          ServiceFace,
+		"http://localhost:8080/proxy/vision/v1.0/models":                        ServiceComputerVision,
+		"http://localhost:8080/face/proxy/emotion/v1.0/recognize":               ServiceEmotion,
+		"http://localhost:8080/proxy/video":                                     ServiceVideo,
+		"http://localhost:8080/proxy":                                           "",
+	} {
+		u, _ := url.Parse(rawUrl)
+		if service := serviceOf(u); service != expected {
+			t.Errorf("serviceOf(%s) = %s, expected: %s", rawUrl, service, expected)
+		}
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Date(2017, 1, 31, 23, 59, 0, 0, time.UTC)
+
+	limiter := NewRateLimiter(RateLimit{PerSecond: 10, Burst: 2, PerMonth: 3, Mode: RateLimitFail})
+	limiter.now = func() time.Time { return now }
+
+	// burst
+	for i := 0; i < 2; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Errorf("Wait() failed: %s", err)
+		}
+	}
+	if err := limiter.Wait(context.Background()); err != ErrRateLimitExceeded || !IsRateLimited(err) {
+		t.Errorf("Wait() should fail with ErrRateLimitExceeded, got: %v", err)
+	}
+
+	// refill
+	now = now.Add(100 * time.Millisecond)
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Errorf("Wait() failed after refill: %s", err)
+	}
+
+	// monthly limit
+	now = now.Add(time.Second)
+	if err := limiter.Wait(context.Background()); err != ErrMonthlyLimitExceeded || !IsQuotaExceeded(err) {
+		t.Errorf("Wait() should fail with ErrMonthlyLimitExceeded, got: %v", err)
+	}
+	if budget := limiter.Remaining(); budget.Month != 0 || budget.Transactions != 2 {
+		t.Errorf("Unexpected budget: %+v", budget)
+	}
+
+	// next month
+	now = now.Add(24 * time.Hour)
+	if budget := limiter.Remaining(); budget.Month != 3 {
+		t.Errorf("Monthly budget was not reset: %+v", budget)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{PerSecond: 100, Burst: 1})
+
+	started := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if err := limiter.Wait(context.Background()); err != nil {
+				t.Errorf("Wait() failed: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 1 at once, 4 more in 10ms intervals
+	if elapsed := time.Since(started); elapsed < 35*time.Millisecond {
+		t.Errorf("Wait() did not wait enough: %s", elapsed)
+	}
+
+	// cancelled while waiting
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewRateLimiter(RateLimit{PerSecond: 0.001, Burst: 1}).Wait(ctx); err != nil {
+		t.Errorf("First Wait() should not wait: %s", err)
+	}
+	limiter = NewRateLimiter(RateLimit{PerSecond: 0.001, Burst: 1})
+	limiter.Wait(context.Background())
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Errorf("Wait() should be cancelled, got: %v", err)
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serviceOf(r.URL) == ServiceFace {
+			io.WriteString(w, `[]`)
+		} else {
+			io.WriteString(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+	client.SetRateLimiter(ServiceFace, "", NewRateLimiter(RateLimit{PerSecond: 1, Mode: RateLimitFail}))
+	client.SetRateLimiter(ServiceFace, "unlimited-key", NewRateLimiter(RateLimit{}))
+
+	// limited with the service's limiter
+	if _, err := client.FaceGetLists(context.Background(), WestUS, "test-key"); err != nil {
+		t.Errorf("FaceGetLists() failed: %s", err)
+	}
+	if _, err := client.FaceGetLists(context.Background(), WestUS, "test-key"); !IsRateLimited(err) {
+		t.Errorf("FaceGetLists() should be rate limited, got: %v", err)
+	}
+
+	// not limited with the key's own limiter
+	for i := 0; i < 3; i++ {
+		if _, err := client.FaceGetLists(context.Background(), WestUS, "unlimited-key"); err != nil {
+			t.Errorf("FaceGetLists() failed: %s", err)
+		}
+	}
+
+	// other services are not limited
+	if _, err := client.ComputerVisionGetModels(context.Background(), WestUS, "test-key"); err != nil {
+		t.Errorf("ComputerVisionGetModels() failed: %s", err)
+	}
+}
+
+func TestClientRateLimiterWithPathPrefix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `[]`)
+	}))
+	defer server.Close()
+
+	client := &Client{Endpoint: MustParseEndpoint(server.URL + "/proxy")}
+	client.SetRateLimiter(ServiceFace, "", NewRateLimiter(RateLimit{PerSecond: 1, Mode: RateLimitFail}))
+
+	// limited with the service's limiter, even with a path prefix
+	if _, err := client.FaceGetLists(context.Background(), WestUS, "test-key"); err != nil {
+		t.Errorf("FaceGetLists() failed: %s", err)
+	}
+	if _, err := client.FaceGetLists(context.Background(), WestUS, "test-key"); !IsRateLimited(err) {
+		t.Errorf("FaceGetLists() should be rate limited, got: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// policy for retrying failed requests
+//
+// requests are retried on:
+//
+// - HTTP 429 (honoring Retry-After header),
+//
+// - HTTP 5xx,
+//
+// - connection resets or timeouts.
+//
+// successful responses (including HTTP 202 of POST requests which started long-running operations) are never retried.
+type RetryPolicy struct {
+	MaxAttempts    int                    // maximum number of attempts including the first one (default: 1 = no retry)
+	InitialBackoff time.Duration          // backoff before the first retry (default: 500ms)
+	MaxBackoff     time.Duration          // maximum backoff between retries (default: 30s)
+	Multiplier     float64                // multiplier of backoff for each retry (default: 2.0)
+	Jitter         float64                // 0.0 ~ 1.0, randomization factor of backoff (default: 0.0)
+	OnRetry        func(event RetryEvent) // called before each retry (can be nil)
+}
+
+// information of a retry
+type RetryEvent struct {
+	Method     string        // http method of the request
+	Url        string        // url of the request
+	Attempt    int           // number of the failed attempt (starting from 1)
+	StatusCode int           // http status code of the failed attempt (0 if no response was received)
+	Err        error         // error of the failed attempt (nil if a response was received)
+	Delay      time.Duration // delay before the next attempt
+}
+
+// default values of RetryPolicy
+const (
+	DefaultRetryInitialBackoff = 500 * time.Millisecond
+	DefaultRetryMaxBackoff     = 30 * time.Second
+	DefaultRetryMultiplier     = 2.0
+)
+
+// create a new retry policy with given maximum attempts, and default values
+func NewRetryPolicy(maxAttempts int) *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: DefaultRetryInitialBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		Multiplier:     DefaultRetryMultiplier,
+		Jitter:         0.2,
+	}
+}
+
+// middleware which retries failed requests with given policy
+//
+// requests with bodies which cannot be rewound are not retried
+func RetryMiddleware(policy *RetryPolicy) Middleware {
+	return retryMiddleware(policy, nil)
+}
+
+// middleware which retries failed requests with given policy, and calls onRetry before each retry (onRetry can be nil)
+func retryMiddleware(policy *RetryPolicy, onRetry func(req *http.Request, event RetryEvent)) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (resp *http.Response, err error) {
+			ctx := req.Context()
+
+			for attempt := 1; ; attempt++ {
+				resp, err = next(req.WithContext(context.WithValue(ctx, attemptKey{}, attempt)))
+				if ctx.Err() != nil {
+					return resp, err
+				}
+
+				delay, retry := policy.shouldRetry(attempt, resp, err)
+				if !retry || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+					return resp, err
+				}
+
+				event := RetryEvent{
+					Method:  req.Method,
+					Url:     req.URL.String(),
+					Attempt: attempt,
+					Err:     err,
+					Delay:   delay,
+				}
+				if resp != nil {
+					event.StatusCode = resp.StatusCode
+
+					io.Copy(ioutil.Discard, resp.Body)
+					resp.Body.Close()
+				}
+				if policy.OnRetry != nil {
+					policy.OnRetry(event)
+				}
+				if onRetry != nil {
+					onRetry(req, event)
+				}
+
+				if err = sleep(ctx, delay); err != nil {
+					return nil, err
+				}
+
+				// rewind request body for the next attempt
+				req = req.Clone(ctx)
+				if req.GetBody != nil {
+					if req.Body, err = req.GetBody(); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+	}
+}
+
+// check if the attempt should be retried, and return the delay before the next attempt
+func (p *RetryPolicy) shouldRetry(attempt int, resp *http.Response, err error) (delay time.Duration, retry bool) {
+	if p == nil || attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var retryAfter time.Duration
+	if err != nil {
+		if !isRetriableError(err) {
+			return 0, false
+		}
+	} else {
+		if !isRetriableStatus(resp.StatusCode) {
+			return 0, false
+		}
+		retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+	}
+
+	delay = p.backoff(attempt)
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay, true
+}
+
+// calculate backoff after given attempt
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, multiplier := p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = DefaultRetryInitialBackoff
+	}
+	if max <= 0 {
+		max = DefaultRetryMaxBackoff
+	}
+	if multiplier < 1.0 {
+		multiplier = DefaultRetryMultiplier
+	}
+
+	backoff := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1.0)
+		backoff += backoff * jitter * (rand.Float64()*2 - 1)
+	}
+	if backoff > float64(max) {
+		backoff = float64(max)
+	}
+	return time.Duration(backoff)
+}
+
+// check if given http status code is retriable
+func isRetriableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// check if given error is retriable (connection resets or timeouts)
+func isRetriableError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// check if given error is transient, so the request may succeed later (retriable statuses, connection resets, timeouts, or client-side rate limits)
+func isTransientError(err error) bool {
+	if isRetriableError(err) || errors.Is(err, ErrRateLimitExceeded) {
+		return true
+	}
+	return isApiError(err, func(e *ApiError) bool {
+		return isRetriableStatus(e.StatusCode)
+	})
+}
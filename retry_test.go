@@ -0,0 +1,110 @@
+package cognitive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     3.0,
+	}
+
+	for attempt, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 300 * time.Millisecond,
+		3: 900 * time.Millisecond,
+		4: time.Second,
+	} {
+		if backoff := policy.backoff(attempt); backoff != expected {
+			t.Errorf("backoff(%d) = %s, expected: %s", attempt, backoff, expected)
+		}
+	}
+
+	// honor Retry-After header
+	resp := &http.Response{StatusCode: 429, Header: http.Header{"Retry-After": []string{"2"}}}
+	if delay, retry := policy.shouldRetry(1, resp, nil); !retry || delay != 2*time.Second {
+		t.Errorf("shouldRetry() = %s, %t", delay, retry)
+	}
+
+	// do not retry client errors, or after the last attempt
+	if _, retry := policy.shouldRetry(1, &http.Response{StatusCode: 400, Header: http.Header{}}, nil); retry {
+		t.Errorf("HTTP 400 should not be retried")
+	}
+	if _, retry := policy.shouldRetry(5, &http.Response{StatusCode: 503, Header: http.Header{}}, nil); retry {
+		t.Errorf("Should not retry after the last attempt")
+	}
+
+	// retry connection resets
+	if _, retry := policy.shouldRetry(1, nil, io.ErrUnexpectedEOF); !retry {
+		t.Errorf("Unexpected EOF should be retried")
+	}
+}
+
+func TestRetryWithServer(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"faceIds":["face-1","face-2"]}` {
+			t.Errorf("Request body was not rewound: %s", string(body))
+		}
+
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `{"groups":[["face-1","face-2"]]}`)
+	}))
+	defer server.Close()
+
+	events := []RetryEvent{}
+	client := &Client{
+		BaseUrl: server.URL,
+		Retry: &RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			OnRetry: func(event RetryEvent) {
+				events = append(events, event)
+			},
+		},
+	}
+
+	if result, err := client.FaceGroup(context.Background(), WestUS, "test-key", []string{"face-1", "face-2"}); err == nil {
+		if len(result.Groups) != 1 {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	} else {
+		t.Errorf("FaceGroup() failed: %s", err)
+	}
+
+	if requests != 3 || len(events) != 2 || events[1].Attempt != 2 || events[1].StatusCode != 503 {
+		t.Errorf("Unexpected retries: %d requests, events: %+v", requests, events)
+	}
+}
+
+func TestRetryNotForAccepted(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+
+		w.Header().Set("Operation-Location", "http://localhost/operations/1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	client := &Client{Retry: NewRetryPolicy(3)}
+	if result, err := client.httpPost(context.Background(), server.URL, "test-key", nil, nil); err != nil || string(result) != "http://localhost/operations/1" {
+		t.Errorf("httpPost() = %s, %v", string(result), err)
+	}
+	if requests != 1 {
+		t.Errorf("HTTP 202 should not be retried: %d requests", requests)
+	}
+}
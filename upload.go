@@ -0,0 +1,105 @@
+package cognitive
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+)
+
+// path of a local file to upload (eg. File("/path/to/video.mp4"))
+//
+// can be given as image/video parameters, and will be streamed without being loaded into memory
+type File string
+
+// io.Reader with its size, for uploading with a correct Content-Length
+//
+// can be given as image/video parameters (plain io.Readers are also accepted, with their sizes detected if possible)
+type SizedReader struct {
+	io.Reader
+	Size int64 // size in bytes (negative if unknown)
+}
+
+// get the size of given reader (-1 if unknown)
+func readerSize(r io.Reader) int64 {
+	switch reader := r.(type) {
+	case SizedReader:
+		return reader.Size
+	case *SizedReader:
+		return reader.Size
+	case interface{ Len() int }: // eg. *bytes.Reader, *bytes.Buffer, *strings.Reader
+		return int64(reader.Len())
+	case *os.File:
+		if info, err := reader.Stat(); err == nil && info.Mode().IsRegular() {
+			if offset, err := reader.Seek(0, io.SeekCurrent); err == nil {
+				return info.Size() - offset
+			}
+		}
+	}
+	return -1
+}
+
+// http post with a stream
+func (c *Client) httpPostReader(ctx context.Context, url, key string, params map[string]string, reader io.Reader, size int64) (result []byte, err error) {
+	var resp *http.Response
+	if resp, err = c.httpRequestStream(ctx, "post", url, key, nil, params, reader, size, "application/octet-stream"); err == nil {
+		return c.readResponse(resp)
+	}
+	return []byte{}, err
+}
+
+// http post with a local file
+func (c *Client) httpPostFile(ctx context.Context, url, key string, params map[string]string, path File) (result []byte, err error) {
+	var file *os.File
+	if file, err = os.Open(string(path)); err != nil {
+		return []byte{}, err
+	}
+	defer file.Close()
+
+	return c.httpPostReader(ctx, url, key, params, file, readerSize(file))
+}
+
+// set body of given request to the reader, so that it can be rewound for retries if possible
+func setRequestBody(req *http.Request, reader io.Reader, size int64) {
+	switch r := reader.(type) {
+	case nil:
+		return
+	case SizedReader:
+		reader = r.Reader
+	case *SizedReader:
+		reader = r.Reader
+	}
+
+	if size < 0 {
+		req.ContentLength = -1 // chunked
+	} else {
+		req.ContentLength = size
+	}
+	if size == 0 {
+		req.Body = http.NoBody
+		return
+	}
+	req.Body = ioutil.NopCloser(reader)
+
+	switch r := reader.(type) {
+	case *os.File: // reopen the file
+		if offset, err := r.Seek(0, io.SeekCurrent); err == nil {
+			name := r.Name()
+			req.GetBody = func() (io.ReadCloser, error) {
+				file, err := os.Open(name)
+				if err == nil {
+					_, err = file.Seek(offset, io.SeekStart)
+				}
+				return file, err
+			}
+		}
+	case io.Seeker: // eg. *bytes.Reader
+		if offset, err := r.Seek(0, io.SeekCurrent); err == nil {
+			req.GetBody = func() (io.ReadCloser, error) {
+				_, err := r.Seek(offset, io.SeekStart)
+				return ioutil.NopCloser(reader), err
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package cognitive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// reader which hides all the other interfaces (eg. io.Seeker) of the wrapped reader
+type plainReader struct {
+	r io.Reader
+}
+
+func (r plainReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+func TestReaderSize(t *testing.T) {
+	content := "\x00\x00\x00\x18ftypmp42test video content"
+
+	if size := readerSize(strings.NewReader(content)); size != int64(len(content)) {
+		t.Errorf("Unexpected size of strings.Reader: %d", size)
+	}
+	if size := readerSize(SizedReader{Reader: plainReader{strings.NewReader(content)}, Size: 4}); size != 4 {
+		t.Errorf("Unexpected size of SizedReader: %d", size)
+	}
+	if size := readerSize(plainReader{strings.NewReader(content)}); size != -1 {
+		t.Errorf("Size of plain reader should be unknown: %d", size)
+	}
+}
+
+func TestPostStream(t *testing.T) {
+	content := "\x00\x00\x00\x18ftypmp42test video content"
+
+	var requests int32
+	var contentLength int64
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentLength = r.ContentLength
+		contentType = r.Header.Get("Content-Type")
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != content {
+			t.Errorf("Unexpected body: %s", string(body))
+		}
+
+		// fail the first request of each upload
+		if atomic.AddInt32(&requests, 1)%2 == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		io.WriteString(w, `{}`)
+	}))
+	defer server.Close()
+
+	dir, err := os.MkdirTemp("", "cognitive")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "video.mp4")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %s", err)
+	}
+
+	client := &Client{Retry: &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}}
+
+	// rewindable inputs are retried
+	for _, arg := range []interface{}{
+		File(path),
+		strings.NewReader(content),
+		SizedReader{Reader: strings.NewReader(content), Size: int64(len(content))},
+	} {
+		atomic.StoreInt32(&requests, 0)
+		if _, err := client.postArg(context.Background(), server.URL, "test-key", nil, arg, MediaVideo); err != nil {
+			t.Errorf("postArg(%T) failed: %s", arg, err)
+		}
+		if requests != 2 || contentLength != int64(len(content)) || contentType != "application/octet-stream" {
+			t.Errorf("Unexpected upload of %T: %d requests, content length: %d, content type: %s", arg, requests, contentLength, contentType)
+		}
+	}
+
+	// non-rewindable stream is not retried, and sent in chunks if its size is unknown
+	atomic.StoreInt32(&requests, 0)
+	if _, err := client.postArg(context.Background(), server.URL, "test-key", nil, plainReader{strings.NewReader(content)}, MediaVideo); !isApiError(err, func(e *ApiError) bool { return e.StatusCode == http.StatusServiceUnavailable }) {
+		t.Errorf("postArg() should fail without retries, got: %v", err)
+	}
+	if requests != 1 || contentLength != -1 {
+		t.Errorf("Unexpected upload of a plain reader: %d requests, content length: %d", requests, contentLength)
+	}
+
+	// not existing file
+	if _, err := client.postArg(context.Background(), server.URL, "test-key", nil, File(filepath.Join(dir, "not-existing.mp4")), MediaVideo); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("postArg() should fail with a not existing file, got: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package cognitive
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// usage of an API operation with a subscription key
+type Usage struct {
+	Key          string  // masked subscription key (eg. "****abcd"), or host of the endpoint for requests without subscription keys
+	Service      Service // service of the operation
+	Operation    string  // name of the operation (eg. "FaceDetect", "VideoMotionDetectPoll")
+	Poll         bool    // true if the requests were polls of long-running operations
+	Transactions int64   // number of successful (billable) requests
+	Failures     int64   // number of failed requests (error responses or no response)
+}
+
+// collector of usages (like prometheus.Collector)
+type UsageCollector interface {
+	Collect(ch chan<- Usage)
+}
+
+// prices of transactions, for estimating costs
+//
+// prices are looked up in order: Operations => Poll (for polls) => Services
+type PriceTable struct {
+	Operations map[string]float64  // price per transaction of each operation (eg. "FaceDetect": 0.001)
+	Services   map[Service]float64 // price per transaction of each service, for operations not in Operations
+	Poll       float64             // price per poll of long-running operations, for polls not in Operations
+}
+
+// price of a transaction of given usage
+func (p *PriceTable) priceOf(u Usage) float64 {
+	if p == nil {
+		return 0
+	}
+	if price, exists := p.Operations[u.Operation]; exists {
+		return price
+	}
+	if u.Poll {
+		return p.Poll
+	}
+	return p.Services[u.Service]
+}
+
+// estimated cost of the usage with given price table
+func (u Usage) Cost(prices *PriceTable) float64 {
+	return float64(u.Transactions) * prices.priceOf(u)
+}
+
+// key of usages
+type usageKey struct {
+	key       string
+	service   Service
+	operation string
+	poll      bool
+}
+
+// recorder which counts transactions per subscription key, service, and operation, safe for concurrent use
+//
+// can be set to Client.Usage, and implements UsageCollector and http.Handler (in prometheus text format)
+type UsageRecorder struct {
+	Prices *PriceTable // prices for estimating costs (nil: costs are not exported)
+
+	mu     sync.Mutex
+	usages map[usageKey]*Usage
+}
+
+// create a new usage recorder with given price table (can be nil)
+func NewUsageRecorder(prices *PriceTable) *UsageRecorder {
+	return &UsageRecorder{
+		Prices: prices,
+		usages: map[usageKey]*Usage{},
+	}
+}
+
+// record a request to given usage
+func (r *UsageRecorder) record(k usageKey, failed bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.usages == nil {
+		r.usages = map[usageKey]*Usage{}
+	}
+	u, exists := r.usages[k]
+	if !exists {
+		u = &Usage{Key: k.key, Service: k.service, Operation: k.operation, Poll: k.poll}
+		r.usages[k] = u
+	}
+	if failed {
+		u.Failures++
+	} else {
+		u.Transactions++
+	}
+}
+
+// send all usages to given channel (implements UsageCollector)
+func (r *UsageRecorder) Collect(ch chan<- Usage) {
+	for _, u := range r.Usages() {
+		ch <- u
+	}
+}
+
+// copy of all usages, sorted by key, service, and operation
+func (r *UsageRecorder) Usages() (usages []Usage) {
+	r.mu.Lock()
+	usages = make([]Usage, 0, len(r.usages))
+	for _, u := range r.usages {
+		usages = append(usages, *u)
+	}
+	r.mu.Unlock()
+
+	sort.Slice(usages, func(i, j int) bool {
+		if usages[i].Key != usages[j].Key {
+			return usages[i].Key < usages[j].Key
+		}
+		if usages[i].Service != usages[j].Service {
+			return usages[i].Service < usages[j].Service
+		}
+		return usages[i].Operation < usages[j].Operation
+	})
+	return usages
+}
+
+// reset all usages (eg. at the beginning of a billing period)
+func (r *UsageRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.usages = map[usageKey]*Usage{}
+}
+
+// estimated total cost of all usages with given price table
+func (r *UsageRecorder) EstimateCost(prices *PriceTable) (cost float64) {
+	for _, u := range r.Usages() {
+		cost += u.Cost(prices)
+	}
+	return cost
+}
+
+// write all usages in prometheus text format
+func (r *UsageRecorder) WritePrometheus(w io.Writer) (err error) {
+	usages := r.Usages()
+
+	var b strings.Builder
+	b.WriteString("# HELP cognitive_transactions_total Number of successful (billable) requests.\n")
+	b.WriteString("# TYPE cognitive_transactions_total counter\n")
+	for _, u := range usages {
+		fmt.Fprintf(&b, "cognitive_transactions_total{%s} %d\n", u.labels(), u.Transactions)
+	}
+	b.WriteString("# HELP cognitive_failures_total Number of failed requests.\n")
+	b.WriteString("# TYPE cognitive_failures_total counter\n")
+	for _, u := range usages {
+		fmt.Fprintf(&b, "cognitive_failures_total{%s} %d\n", u.labels(), u.Failures)
+	}
+	if r.Prices != nil {
+		b.WriteString("# HELP cognitive_estimated_cost Estimated cost of successful requests.\n")
+		b.WriteString("# TYPE cognitive_estimated_cost gauge\n")
+		for _, u := range usages {
+			fmt.Fprintf(&b, "cognitive_estimated_cost{%s} %g\n", u.labels(), u.Cost(r.Prices))
+		}
+	}
+
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// serve all usages in prometheus text format (eg. http.Handle("/metrics", recorder))
+func (r *UsageRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WritePrometheus(w)
+}
+
+// labels of the usage in prometheus text format
+func (u Usage) labels() string {
+	return fmt.Sprintf(`key=%q,service=%q,operation=%q,poll="%t"`, u.Key, string(u.Service), u.Operation, u.Poll)
+}
+
+// key of poll flag in request contexts
+type pollKey struct{}
+
+// mark requests with given context as polls of long-running operations
+func withPoll(ctx context.Context) context.Context {
+	return context.WithValue(ctx, pollKey{}, true)
+}
+
+// check if given request is a poll of a long-running operation
+func isPoll(req *http.Request) bool {
+	poll, _ := req.Context().Value(pollKey{}).(bool)
+	return poll
+}
+
+// mask given subscription key, leaving its last 4 characters only
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return strings.Repeat("*", len(key))
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// middleware which records each attempt of requests to the usage recorder
+//
+// should be placed inside RetryMiddleware() for recording every attempt
+func UsageMiddleware(recorder *UsageRecorder) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			resp, err := next(req)
+
+			id, isKey := resourceIdOf(req)
+			if isKey {
+				id = maskKey(id)
+			}
+
+			k := usageKey{
+				key:       id,
+				service:   serviceOf(req.URL),
+				operation: operationNameOf(req),
+				poll:      isPoll(req),
+			}
+			recorder.record(k, err != nil || resp.StatusCode >= 400)
+
+			return resp, err
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package cognitive
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestUsageRecorder(t *testing.T) {
+	var detects, polls int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/face/v1.0/detect":
+			if atomic.AddInt32(&detects, 1) < 2 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			io.WriteString(w, `[]`)
+		case "/video/v1.0/detectmotion":
+			w.Header().Set("Operation-Location", server.URL+"/video/v1.0/operations/1")
+			w.WriteHeader(http.StatusAccepted)
+		case "/video/v1.0/operations/1":
+			if atomic.AddInt32(&polls, 1) < 3 {
+				io.WriteString(w, `{"status":"Running","progress":50.0}`)
+			} else {
+				io.WriteString(w, `{"status":"Succeeded","progress":100.0,"processingResult":"{}"}`)
+			}
+		}
+	}))
+	defer server.Close()
+
+	prices := &PriceTable{
+		Operations: map[string]float64{"FaceDetect": 0.001},
+		Services:   map[Service]float64{ServiceVideo: 0.1},
+		Poll:       0.0001,
+	}
+	recorder := NewUsageRecorder(prices)
+	client := &Client{
+		BaseUrl:     server.URL,
+		PollOptions: testPollOptions,
+		Retry:       &RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+		Usage:       recorder,
+	}
+
+	if _, err := client.FaceDetect(context.Background(), WestUS, "face-key-1234", "http://localhost/face.jpg", true, false, nil); err != nil {
+		t.Errorf("FaceDetect() failed: %s", err)
+	}
+	if _, err := client.VideoMotionDetect(context.Background(), "video-key-5678", "http://localhost/video.mp4", "", 0, nil, false, 0, nil); err != nil {
+		t.Errorf("VideoMotionDetect() failed: %s", err)
+	}
+
+	usages := recorder.Usages()
+	expected := []Usage{
+		{Key: "****1234", Service: ServiceFace, Operation: "FaceDetect", Transactions: 1, Failures: 1},
+		{Key: "****5678", Service: ServiceVideo, Operation: "VideoMotionDetectPoll", Poll: true, Transactions: 3},
+		{Key: "****5678", Service: ServiceVideo, Operation: "VideoMotionDetectSubmit", Transactions: 1},
+	}
+	if len(usages) != len(expected) {
+		t.Fatalf("Unexpected usages: %+v", usages)
+	}
+	for i, u := range usages {
+		if u != expected[i] {
+			t.Errorf("Unexpected usage #%d: %+v, expected: %+v", i, u, expected[i])
+		}
+	}
+
+	if cost := recorder.EstimateCost(prices); math.Abs(cost-(0.001+0.1+3*0.0001)) > 1e-9 {
+		t.Errorf("Unexpected estimated cost: %g", cost)
+	}
+
+	// collector
+	ch := make(chan Usage, 10)
+	var collector UsageCollector = recorder
+	collector.Collect(ch)
+	if len(ch) != len(expected) {
+		t.Errorf("Unexpected number of collected usages: %d", len(ch))
+	}
+
+	// prometheus text format
+	var buf bytes.Buffer
+	if err := recorder.WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus() failed: %s", err)
+	}
+	for _, line := range []string{
+		`cognitive_transactions_total{key="****1234",service="face",operation="FaceDetect",poll="false"} 1`,
+		`cognitive_failures_total{key="****1234",service="face",operation="FaceDetect",poll="false"} 1`,
+		`cognitive_transactions_total{key="****5678",service="video",operation="VideoMotionDetectPoll",poll="true"} 3`,
+		`cognitive_estimated_cost{key="****5678",service="video",operation="VideoMotionDetectSubmit",poll="false"} 0.1`,
+	} {
+		if !strings.Contains(buf.String(), line+"\n") {
+			t.Errorf("Exported usages should contain %q:\n%s", line, buf.String())
+		}
+	}
+	if strings.Contains(buf.String(), "face-key-1234") {
+		t.Errorf("Exported usages should not contain subscription keys:\n%s", buf.String())
+	}
+
+	recorder.Reset()
+	if usages := recorder.Usages(); len(usages) != 0 {
+		t.Errorf("Usages were not reset: %+v", usages)
+	}
+}
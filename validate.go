@@ -0,0 +1,210 @@
+package cognitive
+
+import (
+	"errors"
+	"fmt"
+)
+
+// errors wrapped in ParameterError, which can be checked with errors.Is()
+var (
+	ErrParameterOutOfRange = errors.New("parameter is out of range")
+	ErrParameterTooLong    = errors.New("parameter is too long")
+	ErrParameterInvalid    = errors.New("parameter is not valid")
+)
+
+// limits of parameters
+const (
+	MaxIdLength           = 64        // of face list ids and person group ids
+	MaxNameLength         = 128       // of face lists, person groups, and persons
+	MaxUserDataLength     = 16 * 1024 // of face lists, person groups, and persons
+	MaxFaceUserDataLength = 1024      // of faces in face lists and persons
+)
+
+// error returned when given parameter is not acceptable, before any request is sent
+type ParameterError struct {
+	Parameter string // name of the parameter (eg. "maxNumOfCandidatesReturned")
+	Message   string // reason of the error
+	Err       error  // underlying error (ErrParameterOutOfRange, ErrParameterTooLong, or ErrParameterInvalid)
+}
+
+func (e *ParameterError) Error() string {
+	return "invalid parameter (" + e.Parameter + "); " + e.Message
+}
+
+func (e *ParameterError) Unwrap() error {
+	return e.Err
+}
+
+// create a ParameterError which wraps ErrParameterInvalid
+func invalidParameter(parameter string, format string, args ...interface{}) error {
+	return &ParameterError{Parameter: parameter, Message: fmt.Sprintf(format, args...), Err: ErrParameterInvalid}
+}
+
+// the first non-nil error of given ones
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check if value is in range: min - max
+func validateRange(parameter string, value, min, max int) error {
+	if value < min || value > max {
+		return &ParameterError{
+			Parameter: parameter,
+			Message:   fmt.Sprintf("%d is not in range: %d - %d", value, min, max),
+			Err:       ErrParameterOutOfRange,
+		}
+	}
+	return nil
+}
+
+// check if value is in range: min - max
+func validateRangeFloat(parameter string, value, min, max float64) error {
+	if value < min || value > max {
+		return &ParameterError{
+			Parameter: parameter,
+			Message:   fmt.Sprintf("%g is not in range: %g - %g", value, min, max),
+			Err:       ErrParameterOutOfRange,
+		}
+	}
+	return nil
+}
+
+// check if value is not negative
+func validateNonNegative(parameter string, value int) error {
+	if value < 0 {
+		return &ParameterError{
+			Parameter: parameter,
+			Message:   fmt.Sprintf("%d is negative", value),
+			Err:       ErrParameterOutOfRange,
+		}
+	}
+	return nil
+}
+
+// check if number of items is in range: min - max
+func validateCount(parameter string, count, min, max int) error {
+	if count < min || count > max {
+		return &ParameterError{
+			Parameter: parameter,
+			Message:   fmt.Sprintf("number of items (%d) is not in range: %d - %d", count, min, max),
+			Err:       ErrParameterOutOfRange,
+		}
+	}
+	return nil
+}
+
+// check if length of value (in bytes) is not longer than max
+func validateLength(parameter, value string, max int) error {
+	if len(value) > max {
+		return &ParameterError{
+			Parameter: parameter,
+			Message:   fmt.Sprintf("length %d is longer than %d", len(value), max),
+			Err:       ErrParameterTooLong,
+		}
+	}
+	return nil
+}
+
+// check if id (of face list or person group) is not empty, and consists of lower case letters, digits, '-', or '_'
+func validateId(parameter, id string) error {
+	if id == "" {
+		return invalidParameter(parameter, "id is empty")
+	}
+	if err := validateLength(parameter, id, MaxIdLength); err != nil {
+		return err
+	}
+	for _, ch := range id {
+		if !(ch >= 'a' && ch <= 'z' || ch >= '0' && ch <= '9' || ch == '-' || ch == '_') {
+			return invalidParameter(parameter, "%q has an invalid character: %q (valid chars: letter in lower case or digit or '-' or '_')", id, ch)
+		}
+	}
+	return nil
+}
+
+// check if id (of person or persisted face) is a GUID, eg. "25985303-c537-4467-b41d-bdb45cd95ca1"
+func validateGuid(parameter, id string) error {
+	if id == "" {
+		return invalidParameter(parameter, "id is empty")
+	}
+	if len(id) != 36 {
+		return invalidParameter(parameter, "%q is not a GUID (eg. 25985303-c537-4467-b41d-bdb45cd95ca1)", id)
+	}
+	for i, ch := range id {
+		switch i {
+		case 8, 13, 18, 23:
+			if ch != '-' {
+				return invalidParameter(parameter, "%q is not a GUID (eg. 25985303-c537-4467-b41d-bdb45cd95ca1)", id)
+			}
+		default:
+			if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'f' || ch >= 'A' && ch <= 'F') {
+				return invalidParameter(parameter, "%q has an invalid character: %q (valid chars: hexadecimal digit or '-')", id, ch)
+			}
+		}
+	}
+	return nil
+}
+
+// check if name and userData (of face list, person group, or person) are not too long
+func validateNameAndUserData(name, userData string) error {
+	if err := validateLength("name", name, MaxNameLength); err != nil {
+		return err
+	}
+	return validateLength("userData", userData, MaxUserDataLength)
+}
+
+// check if all given face attributes are valid
+func validateFaceAttributes(attributes []FaceAttribute) error {
+	for _, attribute := range attributes {
+		if !attribute.IsValid() {
+			return invalidParameter("returnFaceAttributes", "unknown face attribute: %q", attribute)
+		}
+	}
+	return nil
+}
+
+// check if given mode is valid
+func validateFindSimilarMode(mode FindSimilarMode) error {
+	if !mode.IsValid() {
+		return invalidParameter("mode", "unknown mode: %q", mode)
+	}
+	return nil
+}
+
+// check if all given visual features and details are valid
+func validateVisualFeatures(features []VisualFeature, details []Detail) error {
+	for _, feature := range features {
+		if !feature.IsValid() {
+			return invalidParameter("visualFeatures", "unknown visual feature: %q", feature)
+		}
+	}
+	for _, detail := range details {
+		if !detail.IsValid() {
+			return invalidParameter("details", "unknown detail: %q", detail)
+		}
+	}
+	return nil
+}
+
+// check if all points of given zones are in range: 0.0 - 1.0, and each zone has at least 3 points
+func validateDetectionZones(zones [][]Point) error {
+	for i, zone := range zones {
+		if len(zone) < 3 {
+			return invalidParameter("detectionZones", "zone #%d has less than 3 points", i)
+		}
+		for _, point := range zone {
+			if point.X < 0.0 || point.X > 1.0 || point.Y < 0.0 || point.Y > 1.0 {
+				return &ParameterError{
+					Parameter: "detectionZones",
+					Message:   fmt.Sprintf("point (%g, %g) of zone #%d is not in range: 0.0 - 1.0", point.X, point.Y, i),
+					Err:       ErrParameterOutOfRange,
+				}
+			}
+		}
+	}
+	return nil
+}
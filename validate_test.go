@@ -0,0 +1,174 @@
+package cognitive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var requests int
+	var query url.Values
+	var body map[string]interface{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		query = r.URL.Query()
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.Path {
+		case "/video/v1.0/detectmotion":
+			w.Header().Set("Operation-Location", server.URL+"/video/v1.0/operations/1")
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			io.WriteString(w, `[]`)
+		}
+	}))
+	defer server.Close()
+
+	client := &Client{BaseUrl: server.URL}
+	ctx := context.Background()
+
+	// default values are not sent
+	if _, err := client.VideoMotionDetectSubmit(ctx, "key", "http://localhost/video.mp4", "", 0, nil, false, 0.0); err != nil {
+		t.Fatalf("VideoMotionDetectSubmit() failed: %s", err)
+	}
+	if _, exists := query["frameSampleValue"]; exists {
+		t.Errorf("frameSampleValue should not be sent: %v", query)
+	}
+	if _, err := client.VideoMotionDetectSubmit(ctx, "key", "http://localhost/video.mp4", "", 5, nil, false, 2.5); err != nil {
+		t.Fatalf("VideoMotionDetectSubmit() failed: %s", err)
+	}
+	if query.Get("frameSampleValue") != "5" || query.Get("mergeTimeThreshold") != "2.500" {
+		t.Errorf("Unexpected params: %v", query)
+	}
+
+	// candidates are sent as numbers
+	if _, err := client.FaceFindSimilar(ctx, WestUS, "key", "face-1", "list-1", nil, 0, ""); err != nil {
+		t.Fatalf("FaceFindSimilar() failed: %s", err)
+	}
+	if body["maxNumOfCandidatesReturned"] != 20.0 || body["mode"] != "matchPerson" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+	if _, err := client.FaceIdentify(ctx, WestUS, "key", []string{"face-1"}, "group-1", 0, 0.0); err != nil {
+		t.Fatalf("FaceIdentify() failed: %s", err)
+	}
+	if _, exists := body["confidenceThreshold"]; exists || body["maxNumOfCandidatesReturned"] != 1.0 {
+		t.Errorf("Unexpected body: %v", body)
+	}
+
+	// ids in paths
+	if err := client.FaceDeletePersonFace(ctx, WestUS, "key", "group-1", "25985303-c537-4467-b41d-bdb45cd95ca1", "B8B5E7A7-0DF2-4F0B-9E2B-2B6D3E3A8C11"); err != nil {
+		t.Fatalf("FaceDeletePersonFace() failed: %s", err)
+	}
+	if _, err := client.FaceGetPersonGroups(ctx, WestUS, "key", "group-1", 0); err != nil || query.Get("start") != "group-1" {
+		t.Fatalf("FaceGetPersonGroups() failed: %v, %v", err, query)
+	}
+
+	sent := requests
+
+	// invalid parameters
+	for _, test := range []struct {
+		name      string
+		parameter string
+		expected  error
+		call      func() error
+	}{
+		{"frame sampling value", "frameSamplingValue", ErrParameterOutOfRange, func() error {
+			_, err := client.VideoMotionDetectSubmit(ctx, "key", "http://localhost/video.mp4", "", 21, nil, false, 0.0)
+			return err
+		}},
+		{"merge time threshold", "mergeTimeThreshold", ErrParameterOutOfRange, func() error {
+			_, err := client.VideoMotionDetectSubmit(ctx, "key", "http://localhost/video.mp4", "", 0, nil, false, -1.0)
+			return err
+		}},
+		{"detection zones", "detectionZones", ErrParameterOutOfRange, func() error {
+			_, err := client.VideoMotionDetectSubmit(ctx, "key", "http://localhost/video.mp4", "", 0, [][]Point{{{X: 0, Y: 0}, {X: 1.5, Y: 0}, {X: 1, Y: 1}}}, false, 0.0)
+			return err
+		}},
+		{"thumbnail duration", "maxMotionThumbnailDurationInSecs", ErrParameterOutOfRange, func() error {
+			_, err := client.VideoThumbnailSubmit(ctx, "key", "http://localhost/video.mp4", -1, true, true)
+			return err
+		}},
+		{"thumbnail size", "width", ErrParameterOutOfRange, func() error {
+			_, err := client.ComputerVisionGetThumbnail(ctx, WestUS, "key", "http://localhost/image.jpg", 0, 100, false)
+			return err
+		}},
+		{"candidates of find similar", "maxNumOfCandidatesReturned", ErrParameterOutOfRange, func() error {
+			_, err := client.FaceFindSimilar(ctx, WestUS, "key", "face-1", "", []string{"face-2"}, 1001, "")
+			return err
+		}},
+		{"both face list and faces", "faceListId", ErrParameterInvalid, func() error {
+			_, err := client.FaceFindSimilar(ctx, WestUS, "key", "face-1", "list-1", []string{"face-2"}, 0, "")
+			return err
+		}},
+		{"candidates of identify", "maxNumOfCandidatesReturned", ErrParameterOutOfRange, func() error {
+			_, err := client.FaceIdentify(ctx, WestUS, "key", []string{"face-1"}, "group-1", 6, 0.0)
+			return err
+		}},
+		{"confidence threshold", "confidenceThreshold", ErrParameterOutOfRange, func() error {
+			_, err := client.FaceIdentify(ctx, WestUS, "key", []string{"face-1"}, "group-1", 1, 1.5)
+			return err
+		}},
+		{"number of faces", "faceIds", ErrParameterOutOfRange, func() error {
+			_, err := client.FaceIdentify(ctx, WestUS, "key", make([]string, 11), "group-1", 1, 0.5)
+			return err
+		}},
+		{"characters of id", "personGroupId", ErrParameterInvalid, func() error {
+			return client.FaceCreatePersonGroup(ctx, WestUS, "key", "My Group", "name", "")
+		}},
+		{"length of id", "faceListId", ErrParameterTooLong, func() error {
+			return client.FaceDeleteFaceList(ctx, WestUS, "key", strings.Repeat("a", MaxIdLength+1))
+		}},
+		{"length of user data", "userData", ErrParameterTooLong, func() error {
+			return client.FaceCreateFaceList(ctx, WestUS, "key", "list-1", "name", strings.Repeat("a", MaxUserDataLength+1))
+		}},
+		{"length of face user data", "userData", ErrParameterTooLong, func() error {
+			_, err := client.FaceAddFaceToList(ctx, WestUS, "key", "http://localhost/face.jpg", "list-1", strings.Repeat("a", MaxFaceUserDataLength+1), Rectangle{})
+			return err
+		}},
+		{"empty person id", "personId", ErrParameterInvalid, func() error {
+			_, err := client.FaceGetPerson(ctx, WestUS, "key", "group-1", "")
+			return err
+		}},
+		{"person id with a path", "personId", ErrParameterInvalid, func() error {
+			return client.FaceDeletePerson(ctx, WestUS, "key", "group-1", "../../persongroups/group-2")
+		}},
+		{"persisted face id of person", "persistedFaceId", ErrParameterInvalid, func() error {
+			return client.FaceDeletePersonFace(ctx, WestUS, "key", "group-1", "25985303-c537-4467-b41d-bdb45cd95ca1", "25985303-c537-4467-b41d-bdb45cd95ca1/x")
+		}},
+		{"persisted face id of face list", "persistedFaceId", ErrParameterInvalid, func() error {
+			return client.FaceDeleteFace(ctx, WestUS, "key", "list-1", "25985303_c537_4467_b41d_bdb45cd95ca1")
+		}},
+		{"start", "start", ErrParameterInvalid, func() error {
+			_, err := client.FaceGetPersonGroups(ctx, WestUS, "key", "group-1&top=1", 0)
+			return err
+		}},
+		{"top", "top", ErrParameterOutOfRange, func() error {
+			_, err := client.FaceGetPersonGroups(ctx, WestUS, "key", "", 1001)
+			return err
+		}},
+	} {
+		err := test.call()
+
+		var paramErr *ParameterError
+		if !errors.As(err, &paramErr) {
+			t.Errorf("%s: should fail with ParameterError, got: %v", test.name, err)
+			continue
+		}
+		if paramErr.Parameter != test.parameter || !errors.Is(err, test.expected) {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+		}
+	}
+
+	if requests != sent {
+		t.Errorf("Invalid parameters should not be sent: %d requests", requests-sent)
+	}
+}
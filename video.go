@@ -1,12 +1,10 @@
 package cognitive
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type VideoProcessingResult1 struct {
@@ -65,85 +63,220 @@ type VideoProcessingResult2 struct {
 // https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e39
 //
 // key              : subscription key for this API
-// video            : string(video url) or []byte(video bytes array)
+// video            : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
 // progressNotifier : can be nil
+//
+// requested to WestUS (VideoFaceDetectTrackWithOptions() for other locations)
 func VideoFaceDetectTrack(
 	key string,
 	video interface{},
 	progressNotifier func(status string, progress float32),
 ) (processResult VideoProcessingResult1, err error) {
-	const apiUrl = "https://westus.api.cognitive.microsoft.com/video/v1.0/trackface"
+	return VideoFaceDetectTrackWithContext(
+		context.Background(),
+		key,
+		video,
+		progressNotifier,
+	)
+}
 
-	var result []byte
-	result, err = postArg(apiUrl, key, nil, video)
+// Video API: Face Detection and Tracking (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func VideoFaceDetectTrackWithContext(
+	ctx context.Context,
+	key string,
+	video interface{},
+	progressNotifier func(status string, progress float32),
+) (processResult VideoProcessingResult1, err error) {
+	return DefaultClient.VideoFaceDetectTrack(
+		ctx,
+		key,
+		video,
+		progressNotifier,
+	)
+}
 
-	// get video operation result
-	//
-	// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e36
-	if err == nil {
-		opLocation := string(result)
+// Video API: Face Detection and Tracking (with client)
+//
+// same as VideoFaceDetectTrackWithContext(), but requested through this client
+func (c *Client) VideoFaceDetectTrack(
+	ctx context.Context,
+	key string,
+	video interface{},
+	progressNotifier func(status string, progress float32),
+) (processResult VideoProcessingResult1, err error) {
+	return c.VideoFaceDetectTrackWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.progressNotifier = progressNotifier
+		},
+	)
+}
 
-		if IsVerbose {
-			log.Printf("<< Checking operation location...: %s", opLocation)
-		}
+// Video API: Face Detection and Tracking (with options)
+//
+// ctx      : cancels the request and the polling for its result when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithProgress()
+func VideoFaceDetectTrackWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (processResult VideoProcessingResult1, err error) {
+	return DefaultClient.VideoFaceDetectTrackWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
 
-		var lastProgress float32 = -1.0
-
-		for i := 0; i < NumTries; i++ {
-			time.Sleep(WaitSeconds * time.Second)
-
-			var status OperationStatus
-			if result, err = httpGet(opLocation, key, nil); err == nil {
-				if err = json.Unmarshal(result, &status); err == nil {
-					if status.Status == "Succeeded" {
-						if status.ProcessingResultJson != "" {
-							if err = json.Unmarshal([]byte(status.ProcessingResultJson), &processResult); err == nil {
-								return processResult, nil
-							} else {
-								break
-							}
-						} else {
-							err = fmt.Errorf("processingResult is empty")
-							break
-						}
-					} else if status.Status == "Failed" {
-						err = fmt.Errorf(status.Message)
-						break
-					} else {
-						if progressNotifier != nil && lastProgress != status.Progress {
-							progressNotifier(status.Status, status.Progress)
-						}
-
-						if IsVerbose {
-							log.Printf(">> %s (%.2f%%)", status.Status, status.Progress)
-						}
-
-						lastProgress = status.Progress
-					}
-				}
-			}
+// Video API: Face Detection and Tracking (with options and client)
+//
+// same as VideoFaceDetectTrackWithOptions(), but requested through this client
+func (c *Client) VideoFaceDetectTrackWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (processResult VideoProcessingResult1, err error) {
+	var o *options
+	if o, err = newOptions(apiVideoFaceDetectTrack, opts); err != nil {
+		return VideoProcessingResult1{}, err
+	}
 
-			if i+1 == NumTries {
-				err = fmt.Errorf("Reached the limit of tries: %d", NumTries)
-			}
+	var op *Operation
+	if op, err = c.videoFaceDetectTrackSubmit(
+		ctx,
+		location,
+		key,
+		video,
+	); err == nil {
+		if err = op.waitResult(ctx, o.progressNotifier, &processResult); err == nil {
+			return processResult, nil
 		}
 	}
-
 	return VideoProcessingResult1{}, err
 }
 
+// Video API: Face Detection and Tracking (submit)
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e39
+//
+// ctx   : cancels the request when done
+// key   : subscription key for this API
+// video : Media, string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+//
+// submits the video and returns its operation, without waiting for the result
+//
+// requested to WestUS (VideoFaceDetectTrackSubmitWithOptions() for other locations)
+func VideoFaceDetectTrackSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+) (op *Operation, err error) {
+	return DefaultClient.VideoFaceDetectTrackSubmit(
+		ctx,
+		key,
+		video,
+	)
+}
+
+// Video API: Face Detection and Tracking (submit) (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// video    : Media, string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : (no options for this API yet)
+func VideoFaceDetectTrackSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	return DefaultClient.VideoFaceDetectTrackSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Video API: Face Detection and Tracking (submit with client)
+//
+// same as VideoFaceDetectTrackSubmit(), but requested through this client
+func (c *Client) VideoFaceDetectTrackSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+) (op *Operation, err error) {
+	return c.videoFaceDetectTrackSubmit(
+		ctx,
+		WestUS,
+		key,
+		video,
+	)
+}
+
+// Video API: Face Detection and Tracking (submit) (with options and client)
+//
+// same as VideoFaceDetectTrackSubmitWithOptions(), but requested through this client
+func (c *Client) VideoFaceDetectTrackSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	if _, err = newOptions(apiVideoFaceDetectTrackSubmit, opts); err != nil {
+		return nil, err
+	}
+	return c.videoFaceDetectTrackSubmit(ctx, location, key, video)
+}
+
+// submit (for VideoFaceDetectTrackSubmit(), VideoFaceDetectTrackSubmitWithOptions(), and VideoFaceDetectTrackWithOptions())
+func (c *Client) videoFaceDetectTrackSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+) (op *Operation, err error) {
+	apiUrl := c.apiUrl(location, "/video/v1.0/trackface")
+
+	var result []byte
+	if result, err = c.postArg(ctx, apiUrl, key, nil, video, MediaVideo); err == nil {
+		return c.submittedOperation(OperationVideoFaceDetectTrack, result, key)
+	}
+	return nil, err
+}
+
 // Video API: Motion Detection
 //
 // https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e3a
 //
 // key                : subscription key for this API
-// video              : string(video url) or []byte(video bytes array)
+// video              : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
 // sensitivityLevel   : "low", "medium", or "high" (default: "medium")
-// frameSamplingValue : 1 ~ 20 (default: 1)
+// frameSamplingValue : 1 ~ 20, or 0 for default (1)
 // detectionZones     : can be nil
 // detectLightChange  : default false
 // mergeTimeThreshold : 0.0 ~ 10.0 (default: 0.0)
 // progressNotifier   : can be nil
+//
+// requested to WestUS (VideoMotionDetectWithOptions() for other locations)
 func VideoMotionDetect(
 	key string,
 	video interface{},
@@ -154,14 +287,267 @@ func VideoMotionDetect(
 	mergeTimeThreshold float64,
 	progressNotifier func(status string, progress float32),
 ) (processResult VideoProcessingResult2, err error) {
-	const apiUrl = "https://westus.api.cognitive.microsoft.com/video/v1.0/detectmotion"
+	return VideoMotionDetectWithContext(
+		context.Background(),
+		key,
+		video,
+		sensitivityLevel,
+		frameSamplingValue,
+		detectionZones,
+		detectLightChange,
+		mergeTimeThreshold,
+		progressNotifier,
+	)
+}
+
+// Video API: Motion Detection (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func VideoMotionDetectWithContext(
+	ctx context.Context,
+	key string,
+	video interface{},
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+	progressNotifier func(status string, progress float32),
+) (processResult VideoProcessingResult2, err error) {
+	return DefaultClient.VideoMotionDetect(
+		ctx,
+		key,
+		video,
+		sensitivityLevel,
+		frameSamplingValue,
+		detectionZones,
+		detectLightChange,
+		mergeTimeThreshold,
+		progressNotifier,
+	)
+}
+
+// Video API: Motion Detection (with client)
+//
+// same as VideoMotionDetectWithContext(), but requested through this client
+func (c *Client) VideoMotionDetect(
+	ctx context.Context,
+	key string,
+	video interface{},
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+	progressNotifier func(status string, progress float32),
+) (processResult VideoProcessingResult2, err error) {
+	return c.VideoMotionDetectWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.sensitivityLevel = SensitivityLevel(sensitivityLevel)
+			o.frameSamplingValue = frameSamplingValue
+			o.detectionZones = detectionZones
+			o.detectLightChange = detectLightChange
+			o.mergeTimeThreshold = mergeTimeThreshold
+			o.progressNotifier = progressNotifier
+		},
+	)
+}
+
+// Video API: Motion Detection (with options)
+//
+// ctx      : cancels the request and the polling for its result when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithSensitivity(), WithFrameSamplingValue(), WithDetectionZones(), WithDetectLightChange(), WithMergeTimeThreshold(), WithProgress()
+func VideoMotionDetectWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (processResult VideoProcessingResult2, err error) {
+	return DefaultClient.VideoMotionDetectWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Video API: Motion Detection (with options and client)
+//
+// same as VideoMotionDetectWithOptions(), but requested through this client
+func (c *Client) VideoMotionDetectWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (processResult VideoProcessingResult2, err error) {
+	var o *options
+	if o, err = newOptions(apiVideoMotionDetect, opts); err != nil {
+		return VideoProcessingResult2{}, err
+	}
+
+	var op *Operation
+	if op, err = c.videoMotionDetectSubmit(
+		ctx,
+		location,
+		key,
+		video,
+		o,
+	); err == nil {
+		if err = op.waitResult(ctx, o.progressNotifier, &processResult); err == nil {
+			return processResult, nil
+		}
+	}
+	return VideoProcessingResult2{}, err
+}
+
+// Video API: Motion Detection (submit)
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e3a
+//
+// ctx                : cancels the request when done
+// key                : subscription key for this API
+// video              : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// sensitivityLevel   : "low", "medium", or "high" (default: "medium")
+// frameSamplingValue : 1 ~ 20, or 0 for default (1)
+// detectionZones     : can be nil
+// detectLightChange  : default false
+// mergeTimeThreshold : 0.0 ~ 10.0 (default: 0.0)
+//
+// submits the video and returns its operation, without waiting for the result
+//
+// requested to WestUS (VideoMotionDetectSubmitWithOptions() for other locations)
+func VideoMotionDetectSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+) (op *Operation, err error) {
+	return DefaultClient.VideoMotionDetectSubmit(
+		ctx,
+		key,
+		video,
+		sensitivityLevel,
+		frameSamplingValue,
+		detectionZones,
+		detectLightChange,
+		mergeTimeThreshold,
+	)
+}
+
+// Video API: Motion Detection (submit with client)
+//
+// same as VideoMotionDetectSubmit(), but requested through this client
+func (c *Client) VideoMotionDetectSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+	sensitivityLevel string,
+	frameSamplingValue int,
+	detectionZones [][]Point,
+	detectLightChange bool,
+	mergeTimeThreshold float64,
+) (op *Operation, err error) {
+	return c.VideoMotionDetectSubmitWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.sensitivityLevel = SensitivityLevel(sensitivityLevel)
+			o.frameSamplingValue = frameSamplingValue
+			o.detectionZones = detectionZones
+			o.detectLightChange = detectLightChange
+			o.mergeTimeThreshold = mergeTimeThreshold
+		},
+	)
+}
+
+// Video API: Motion Detection (submit) (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithSensitivity(), WithFrameSamplingValue(), WithDetectionZones(), WithDetectLightChange(), WithMergeTimeThreshold()
+func VideoMotionDetectSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	return DefaultClient.VideoMotionDetectSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Video API: Motion Detection (submit) (with options and client)
+//
+// same as VideoMotionDetectSubmitWithOptions(), but requested through this client
+func (c *Client) VideoMotionDetectSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	var o *options
+	if o, err = newOptions(apiVideoMotionDetectSubmit, opts); err != nil {
+		return nil, err
+	}
+	return c.videoMotionDetectSubmit(ctx, location, key, video, o)
+}
+
+// submit with given options (for VideoMotionDetectSubmitWithOptions() and VideoMotionDetectWithOptions())
+func (c *Client) videoMotionDetectSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	o *options,
+) (op *Operation, err error) {
+	sensitivityLevel, frameSamplingValue, detectionZones, detectLightChange, mergeTimeThreshold := o.sensitivityLevel, o.frameSamplingValue, o.detectionZones, o.detectLightChange, o.mergeTimeThreshold
+
+	if sensitivityLevel != "" && !sensitivityLevel.IsValid() {
+		err = invalidParameter("sensitivityLevel", "unknown sensitivity level: %q", sensitivityLevel)
+	}
+	if frameSamplingValue != 0 {
+		err = firstError(err, validateRange("frameSamplingValue", frameSamplingValue, 1, 20))
+	}
+	if err = firstError(
+		err,
+		validateDetectionZones(detectionZones),
+		validateRangeFloat("mergeTimeThreshold", mergeTimeThreshold, 0.0, 10.0),
+	); err != nil {
+		return nil, err
+	}
+
+	apiUrl := c.apiUrl(location, "/video/v1.0/detectmotion")
 
 	// params
 	params := map[string]string{}
 	if sensitivityLevel != "" {
-		params["sensitivityLevel"] = sensitivityLevel
+		params["sensitivityLevel"] = sensitivityLevel.String()
 	}
-	if frameSamplingValue >= 1 || frameSamplingValue <= 20 {
+	if frameSamplingValue != 0 {
 		params["frameSampleValue"] = strconv.Itoa(frameSamplingValue)
 	}
 	if len(detectionZones) > 0 {
@@ -179,66 +565,15 @@ func VideoMotionDetect(
 	if detectLightChange {
 		params["detectLightChange"] = "true"
 	}
-	if mergeTimeThreshold >= 0.0 && mergeTimeThreshold <= 10.0 {
+	if mergeTimeThreshold > 0.0 {
 		params["mergeTimeThreshold"] = fmt.Sprintf("%.3f", mergeTimeThreshold)
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, video)
-
-	// get video operation result
-	//
-	// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e36
-	if err == nil {
-		opLocation := string(result)
-
-		if IsVerbose {
-			log.Printf("<< Checking operation location...: %s", opLocation)
-		}
-
-		var lastProgress float32 = -1.0
-
-		for i := 0; i < NumTries; i++ {
-			time.Sleep(WaitSeconds * time.Second)
-
-			var status OperationStatus
-			if result, err = httpGet(opLocation, key, nil); err == nil {
-				if err = json.Unmarshal(result, &status); err == nil {
-					if status.Status == "Succeeded" {
-						if status.ProcessingResultJson != "" {
-							if err = json.Unmarshal([]byte(status.ProcessingResultJson), &processResult); err == nil {
-								return processResult, nil
-							} else {
-								break
-							}
-						} else {
-							err = fmt.Errorf("processingResult is empty")
-							break
-						}
-					} else if status.Status == "Failed" {
-						err = fmt.Errorf(status.Message)
-						break
-					} else {
-						if progressNotifier != nil && lastProgress != status.Progress {
-							progressNotifier(status.Status, status.Progress)
-						}
-
-						if IsVerbose {
-							log.Printf(">> %s (%.2f%%)", status.Status, status.Progress)
-						}
-
-						lastProgress = status.Progress
-					}
-				}
-			}
-
-			if i+1 == NumTries {
-				err = fmt.Errorf("Reached the limit of tries: %d", NumTries)
-			}
-		}
+	if result, err = c.postArg(ctx, apiUrl, key, params, video, MediaVideo); err == nil {
+		return c.submittedOperation(OperationVideoMotionDetect, result, key)
 	}
-
-	return VideoProcessingResult2{}, err
+	return nil, err
 }
 
 // Video API: Stabilization
@@ -246,79 +581,218 @@ func VideoMotionDetect(
 // https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e35
 //
 // key              : subscription key for this API
-// video            : string(video url) or []byte(video bytes array)
+// video            : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
 // progressNotifier : can be nil
+//
+// requested to WestUS (VideoStabilizeWithOptions() for other locations)
 func VideoStabilize(
 	key string,
 	video interface{},
 	progressNotifier func(status string, progress float32),
 ) (fileUrl string, err error) {
-	const apiUrl = "https://westus.api.cognitive.microsoft.com/video/v1.0/stabilize"
+	return VideoStabilizeWithContext(
+		context.Background(),
+		key,
+		video,
+		progressNotifier,
+	)
+}
 
-	var result []byte
-	result, err = postArg(apiUrl, key, nil, video)
+// Video API: Stabilization (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func VideoStabilizeWithContext(
+	ctx context.Context,
+	key string,
+	video interface{},
+	progressNotifier func(status string, progress float32),
+) (fileUrl string, err error) {
+	return DefaultClient.VideoStabilize(
+		ctx,
+		key,
+		video,
+		progressNotifier,
+	)
+}
 
-	// get video operation result
-	//
-	// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e36
-	if err == nil {
-		opLocation := string(result)
+// Video API: Stabilization (with client)
+//
+// same as VideoStabilizeWithContext(), but requested through this client
+func (c *Client) VideoStabilize(
+	ctx context.Context,
+	key string,
+	video interface{},
+	progressNotifier func(status string, progress float32),
+) (fileUrl string, err error) {
+	return c.VideoStabilizeWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.progressNotifier = progressNotifier
+		},
+	)
+}
 
-		if IsVerbose {
-			log.Printf("<< Checking operation location...: %s", opLocation)
-		}
+// Video API: Stabilization (with options)
+//
+// ctx      : cancels the request and the polling for its result when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithProgress()
+func VideoStabilizeWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (fileUrl string, err error) {
+	return DefaultClient.VideoStabilizeWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
 
-		var lastProgress float32 = -1.0
-
-		for i := 0; i < NumTries; i++ {
-			time.Sleep(WaitSeconds * time.Second)
-
-			var status OperationStatus
-			if result, err = httpGet(opLocation, key, nil); err == nil {
-				if err = json.Unmarshal(result, &status); err == nil {
-					if status.Status == "Succeeded" {
-						if status.ResourceLocation != "" {
-							return status.ResourceLocation, nil
-						} else {
-							err = fmt.Errorf("resourceLocation is empty")
-							break
-						}
-					} else if status.Status == "Failed" {
-						err = fmt.Errorf(status.Message)
-						break
-					} else {
-						if progressNotifier != nil && lastProgress != status.Progress {
-							progressNotifier(status.Status, status.Progress)
-						}
-
-						if IsVerbose {
-							log.Printf(">> %s (%.2f%%)", status.Status, status.Progress)
-						}
-
-						lastProgress = status.Progress
-					}
-				}
-			}
+// Video API: Stabilization (with options and client)
+//
+// same as VideoStabilizeWithOptions(), but requested through this client
+func (c *Client) VideoStabilizeWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (fileUrl string, err error) {
+	var o *options
+	if o, err = newOptions(apiVideoStabilize, opts); err != nil {
+		return "", err
+	}
 
-			if i+1 == NumTries {
-				err = fmt.Errorf("Reached the limit of tries: %d", NumTries)
-			}
+	var op *Operation
+	if op, err = c.videoStabilizeSubmit(
+		ctx,
+		location,
+		key,
+		video,
+	); err == nil {
+		if err = op.waitResult(ctx, o.progressNotifier, &fileUrl); err == nil {
+			return fileUrl, nil
 		}
 	}
-
 	return "", err
 }
 
+// Video API: Stabilization (submit)
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e35
+//
+// ctx   : cancels the request when done
+// key   : subscription key for this API
+// video : Media, string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+//
+// submits the video and returns its operation, without waiting for the result
+//
+// requested to WestUS (VideoStabilizeSubmitWithOptions() for other locations)
+func VideoStabilizeSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+) (op *Operation, err error) {
+	return DefaultClient.VideoStabilizeSubmit(
+		ctx,
+		key,
+		video,
+	)
+}
+
+// Video API: Stabilization (submit) (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// video    : Media, string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : (no options for this API yet)
+func VideoStabilizeSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	return DefaultClient.VideoStabilizeSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Video API: Stabilization (submit with client)
+//
+// same as VideoStabilizeSubmit(), but requested through this client
+func (c *Client) VideoStabilizeSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+) (op *Operation, err error) {
+	return c.videoStabilizeSubmit(
+		ctx,
+		WestUS,
+		key,
+		video,
+	)
+}
+
+// Video API: Stabilization (submit) (with options and client)
+//
+// same as VideoStabilizeSubmitWithOptions(), but requested through this client
+func (c *Client) VideoStabilizeSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	if _, err = newOptions(apiVideoStabilizeSubmit, opts); err != nil {
+		return nil, err
+	}
+	return c.videoStabilizeSubmit(ctx, location, key, video)
+}
+
+// submit (for VideoStabilizeSubmit(), VideoStabilizeSubmitWithOptions(), and VideoStabilizeWithOptions())
+func (c *Client) videoStabilizeSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+) (op *Operation, err error) {
+	apiUrl := c.apiUrl(location, "/video/v1.0/stabilize")
+
+	var result []byte
+	if result, err = c.postArg(ctx, apiUrl, key, nil, video, MediaVideo); err == nil {
+		return c.submittedOperation(OperationVideoStabilize, result, key)
+	}
+	return nil, err
+}
+
 // Video API: Thumbnail
 //
 // https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/56f8acb0778daf23d8ec6738
 //
 // key                              : subscription key for this API
-// video                            : string(video url) or []byte(video bytes array)
+// video                            : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
 // maxMotionThumbnailDurationInSecs : default 0
 // outputAudio                      : default true
 // fadeInFadeOut                    : default true
 // progressNotifier                 : can be nil
+//
+// requested to WestUS (VideoThumbnailWithOptions() for other locations)
 func VideoThumbnail(
 	key string,
 	video interface{},
@@ -327,7 +801,230 @@ func VideoThumbnail(
 	fadeInFadeOut bool,
 	progressNotifier func(status string, progress float32),
 ) (fileUrl string, err error) {
-	const apiUrl = "https://westus.api.cognitive.microsoft.com/video/v1.0/generatethumbnail"
+	return VideoThumbnailWithContext(
+		context.Background(),
+		key,
+		video,
+		maxMotionThumbnailDurationInSecs,
+		outputAudio,
+		fadeInFadeOut,
+		progressNotifier,
+	)
+}
+
+// Video API: Thumbnail (with context)
+//
+// ctx : cancels the request and the polling for its result when done
+func VideoThumbnailWithContext(
+	ctx context.Context,
+	key string,
+	video interface{},
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+	progressNotifier func(status string, progress float32),
+) (fileUrl string, err error) {
+	return DefaultClient.VideoThumbnail(
+		ctx,
+		key,
+		video,
+		maxMotionThumbnailDurationInSecs,
+		outputAudio,
+		fadeInFadeOut,
+		progressNotifier,
+	)
+}
+
+// Video API: Thumbnail (with client)
+//
+// same as VideoThumbnailWithContext(), but requested through this client
+func (c *Client) VideoThumbnail(
+	ctx context.Context,
+	key string,
+	video interface{},
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+	progressNotifier func(status string, progress float32),
+) (fileUrl string, err error) {
+	return c.VideoThumbnailWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.maxMotionThumbnailDurationInSecs = maxMotionThumbnailDurationInSecs
+			o.outputAudio = outputAudio
+			o.fadeInFadeOut = fadeInFadeOut
+			o.progressNotifier = progressNotifier
+		},
+	)
+}
+
+// Video API: Thumbnail (with options)
+//
+// ctx      : cancels the request and the polling for its result when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithMaxThumbnailDuration(), WithoutAudio(), WithoutFadeInFadeOut(), WithProgress()
+func VideoThumbnailWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (fileUrl string, err error) {
+	return DefaultClient.VideoThumbnailWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Video API: Thumbnail (with options and client)
+//
+// same as VideoThumbnailWithOptions(), but requested through this client
+func (c *Client) VideoThumbnailWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (fileUrl string, err error) {
+	var o *options
+	if o, err = newOptions(apiVideoThumbnail, opts); err != nil {
+		return "", err
+	}
+
+	var op *Operation
+	if op, err = c.videoThumbnailSubmit(
+		ctx,
+		location,
+		key,
+		video,
+		o,
+	); err == nil {
+		if err = op.waitResult(ctx, o.progressNotifier, &fileUrl); err == nil {
+			return fileUrl, nil
+		}
+	}
+	return "", err
+}
+
+// Video API: Thumbnail (submit)
+//
+// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/56f8acb0778daf23d8ec6738
+//
+// ctx                              : cancels the request when done
+// key                              : subscription key for this API
+// video                            : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// maxMotionThumbnailDurationInSecs : default 0
+// outputAudio                      : default true
+// fadeInFadeOut                    : default true
+//
+// submits the video and returns its operation, without waiting for the result
+//
+// requested to WestUS (VideoThumbnailSubmitWithOptions() for other locations)
+func VideoThumbnailSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+) (op *Operation, err error) {
+	return DefaultClient.VideoThumbnailSubmit(
+		ctx,
+		key,
+		video,
+		maxMotionThumbnailDurationInSecs,
+		outputAudio,
+		fadeInFadeOut,
+	)
+}
+
+// Video API: Thumbnail (submit with client)
+//
+// same as VideoThumbnailSubmit(), but requested through this client
+func (c *Client) VideoThumbnailSubmit(
+	ctx context.Context,
+	key string,
+	video interface{},
+	maxMotionThumbnailDurationInSecs int,
+	outputAudio bool,
+	fadeInFadeOut bool,
+) (op *Operation, err error) {
+	return c.VideoThumbnailSubmitWithOptions(
+		ctx,
+		WestUS,
+		key,
+		video,
+		func(o *options) {
+			o.maxMotionThumbnailDurationInSecs = maxMotionThumbnailDurationInSecs
+			o.outputAudio = outputAudio
+			o.fadeInFadeOut = fadeInFadeOut
+		},
+	)
+}
+
+// Video API: Thumbnail (submit) (with options)
+//
+// ctx      : cancels the request when done
+// location : API location
+// key      : subscription key for this API
+// video    : string(video url), []byte(video bytes array), File(video file path), or io.Reader(video stream)
+// opts     : WithMaxThumbnailDuration(), WithoutAudio(), WithoutFadeInFadeOut()
+func VideoThumbnailSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	return DefaultClient.VideoThumbnailSubmitWithOptions(
+		ctx,
+		location,
+		key,
+		video,
+		opts...,
+	)
+}
+
+// Video API: Thumbnail (submit) (with options and client)
+//
+// same as VideoThumbnailSubmitWithOptions(), but requested through this client
+func (c *Client) VideoThumbnailSubmitWithOptions(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	opts ...Option,
+) (op *Operation, err error) {
+	var o *options
+	if o, err = newOptions(apiVideoThumbnailSubmit, opts); err != nil {
+		return nil, err
+	}
+	return c.videoThumbnailSubmit(ctx, location, key, video, o)
+}
+
+// submit with given options (for VideoThumbnailSubmitWithOptions() and VideoThumbnailWithOptions())
+func (c *Client) videoThumbnailSubmit(
+	ctx context.Context,
+	location ApiLocation,
+	key string,
+	video interface{},
+	o *options,
+) (op *Operation, err error) {
+	maxMotionThumbnailDurationInSecs, outputAudio, fadeInFadeOut := o.maxMotionThumbnailDurationInSecs, o.outputAudio, o.fadeInFadeOut
+
+	if err = validateNonNegative("maxMotionThumbnailDurationInSecs", maxMotionThumbnailDurationInSecs); err != nil {
+		return nil, err
+	}
+
+	apiUrl := c.apiUrl(location, "/video/v1.0/generatethumbnail")
 
 	// params
 	params := map[string]string{}
@@ -342,55 +1039,8 @@ func VideoThumbnail(
 	}
 
 	var result []byte
-	result, err = postArg(apiUrl, key, params, video)
-
-	// get video operation result
-	//
-	// https://westus.dev.cognitive.microsoft.com/docs/services/565d6516778daf15800928d5/operations/565d6517778daf0978c45e36
-	if err == nil {
-		opLocation := string(result)
-
-		if IsVerbose {
-			log.Printf("<< Checking operation location...: %s", opLocation)
-		}
-
-		var lastProgress float32 = -1.0
-
-		for i := 0; i < NumTries; i++ {
-			time.Sleep(WaitSeconds * time.Second)
-
-			var status OperationStatus
-			if result, err = httpGet(opLocation, key, nil); err == nil {
-				if err = json.Unmarshal(result, &status); err == nil {
-					if status.Status == "Succeeded" {
-						if status.ResourceLocation != "" {
-							return status.ResourceLocation, nil
-						} else {
-							err = fmt.Errorf("resourceLocation is empty")
-							break
-						}
-					} else if status.Status == "Failed" {
-						err = fmt.Errorf(status.Message)
-						break
-					} else {
-						if progressNotifier != nil && lastProgress != status.Progress {
-							progressNotifier(status.Status, status.Progress)
-						}
-
-						if IsVerbose {
-							log.Printf(">> %s (%.2f%%)", status.Status, status.Progress)
-						}
-
-						lastProgress = status.Progress
-					}
-				}
-			}
-
-			if i+1 == NumTries {
-				err = fmt.Errorf("Reached the limit of tries: %d", NumTries)
-			}
-		}
+	if result, err = c.postArg(ctx, apiUrl, key, params, video, MediaVideo); err == nil {
+		return c.submittedOperation(OperationVideoThumbnail, result, key)
 	}
-
-	return "", err
+	return nil, err
 }